@@ -0,0 +1,122 @@
+package tables
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AggregateFunc reduces the cells of a column to a single value, for footers,
+// group subtotals and pivots.
+type AggregateFunc func(values []string) string
+
+// parseNumber reads a numeric cell, allowing thousands separators and
+// surrounding spaces. It also returns the number of digits after the decimal
+// point.
+func parseNumber(s string) (value float64, decimals int, ok bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(StripANSI(s)), ",", "")
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		decimals = len(s) - i - 1
+		if e := strings.IndexAny(s, "eE"); e > i {
+			decimals = e - i - 1
+		}
+	}
+	return value, decimals, true
+}
+
+// numbers returns the numeric cells among values and the most decimals any of
+// them was written with.
+func numbers(values []string) (nums []float64, decimals int) {
+	for _, val := range values {
+		if num, d, ok := parseNumber(val); ok {
+			nums = append(nums, num)
+			decimals = max(decimals, d)
+		}
+	}
+	return
+}
+
+// Sum adds up the numeric cells, keeping as many decimals as the most precise
+// of them. Cells that aren't numbers are skipped.
+func Sum(values []string) string {
+	nums, decimals := numbers(values)
+	if len(nums) == 0 {
+		return ""
+	}
+	total := 0.0
+	for _, num := range nums {
+		total += num
+	}
+	return strconv.FormatFloat(total, 'f', decimals, 64)
+}
+
+// Avg averages the numeric cells, to two more decimals than the most precise of
+// them. Cells that aren't numbers are skipped.
+func Avg(values []string) string {
+	nums, decimals := numbers(values)
+	if len(nums) == 0 {
+		return ""
+	}
+	total := 0.0
+	for _, num := range nums {
+		total += num
+	}
+	avg := strconv.FormatFloat(total/float64(len(nums)), 'f', decimals+2, 64)
+	avg = strings.TrimRight(avg, "0")
+	return strings.TrimSuffix(avg, ".")
+}
+
+// Min returns the smallest numeric cell as it was written.
+func Min(values []string) string {
+	return extreme(values, func(a, b float64) bool { return a < b })
+}
+
+// Max returns the largest numeric cell as it was written.
+func Max(values []string) string {
+	return extreme(values, func(a, b float64) bool { return a > b })
+}
+
+func extreme(values []string, better func(a, b float64) bool) (result string) {
+	var best float64
+	found := false
+	for _, val := range values {
+		if num, _, ok := parseNumber(val); ok && (!found || better(num, best)) {
+			best, result, found = num, strings.TrimSpace(val), true
+		}
+	}
+	return
+}
+
+// Count counts the cells that aren't empty.
+func Count(values []string) string {
+	count := 0
+	for _, val := range values {
+		if strings.TrimSpace(val) != "" {
+			count++
+		}
+	}
+	return strconv.Itoa(count)
+}
+
+// CountDistinct counts the different values among the cells that aren't empty.
+func CountDistinct(values []string) string {
+	seen := make(map[string]bool)
+	for _, val := range values {
+		if strings.TrimSpace(val) != "" {
+			seen[val] = true
+		}
+	}
+	return strconv.Itoa(len(seen))
+}
+
+// columnValues returns the cells of the column at index col in rows.
+func columnValues(rows [][]string, col int) []string {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = row[col]
+	}
+	return values
+}
@@ -0,0 +1,80 @@
+package tables
+
+import (
+	"strings"
+)
+
+// StripANSI removes terminal escape sequences from s: CSI sequences such as SGR
+// colors, OSC sequences such as OSC 8 hyperlinks, and the other string and
+// two-character escapes.
+func StripANSI(s string) string {
+	if !hasEscapes(s) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
+
+func hasEscapes(s string) bool {
+	return strings.ContainsAny(s, "\x1b\u009b\u009d")
+}
+
+// escapeLen returns the length in bytes of the escape sequence s starts with, or
+// 0 if it doesn't start with one. An unterminated sequence runs to the end of s.
+func escapeLen(s string) int {
+	var i int
+	switch {
+	case strings.HasPrefix(s, "\x1b["):
+		i = 2
+	case strings.HasPrefix(s, "\u009b"):
+		i = 2
+	case strings.HasPrefix(s, "\x1b]"), strings.HasPrefix(s, "\x1bP"), strings.HasPrefix(s, "\x1bX"),
+		strings.HasPrefix(s, "\x1b^"), strings.HasPrefix(s, "\x1b_"):
+		return stringEscapeLen(s, 2)
+	case strings.HasPrefix(s, "\u009d"):
+		return stringEscapeLen(s, 2)
+	case strings.HasPrefix(s, "\x1b"):
+		// Two-character escapes, possibly with intermediate bytes such as the
+		// character set designations ESC ( B.
+		for i = 1; i < len(s) && s[i] >= 0x20 && s[i] <= 0x2F; i++ {
+		}
+		if i < len(s) {
+			i++
+		}
+		return i
+	default:
+		return 0
+	}
+	// CSI: parameter and intermediate bytes up to a final byte.
+	for ; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7E {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// stringEscapeLen finds the end of an OSC, DCS, SOS, PM or APC sequence, which is
+// terminated by BEL or the string terminator ESC \.
+func stringEscapeLen(s string, start int) int {
+	for i := start; i < len(s); i++ {
+		if s[i] == '\a' {
+			return i + 1
+		}
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+			return i + 2
+		}
+		if strings.HasPrefix(s[i:], "\u009c") {
+			return i + 2
+		}
+	}
+	return len(s)
+}
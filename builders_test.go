@@ -0,0 +1,140 @@
+package tables
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLevel int
+
+func (l testLevel) String() string {
+	return [...]string{"low", "high"}[l]
+}
+
+type testAudit struct {
+	Created time.Time `tabula:",format=2006-01-02"`
+	secret  string
+}
+
+type testItem struct {
+	testAudit
+	Name   string
+	Price  float64 `tabula:"Unit Price,align=right,format=%.2f"`
+	Level  testLevel
+	Parent *testItem `tabula:"-"`
+	Note   *string
+	Skip   bool `tabula:",omit"`
+}
+
+type testNode struct {
+	*testNode
+	Name string
+}
+
+type testAddress struct {
+	Name string
+	City string
+}
+
+func TestFromStructs(t *testing.T) {
+	note := "fragile"
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	items := []*testItem{
+		{testAudit: testAudit{Created: created, secret: "x"}, Name: "Widget", Price: 3.14159, Level: 1, Note: &note},
+		{Name: "Gadget", Price: 120.5},
+		nil,
+	}
+	tbl, err := FromStructs(items)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expectedColumns := []string{"Created", "Name", "Unit Price", "Level", "Note"}
+	if len(tbl.columns) != len(expectedColumns) {
+		t.Fatalf("Expected columns %v, got %v", expectedColumns, tbl.columns)
+	}
+	for i, col := range expectedColumns {
+		if tbl.columns[i] != col {
+			t.Errorf("Expected column %d to be %q, got %q", i, col, tbl.columns[i])
+		}
+	}
+	expectedRows := [][]string{
+		{"2021-03-04", "Widget", "3.14", "high", "fragile"},
+		{"0001-01-01", "Gadget", "120.50", "low", ""},
+		{"", "", "", "", ""},
+	}
+	for r, row := range expectedRows {
+		for c, cell := range row {
+			if tbl.rows[r][c] != cell {
+				t.Errorf("Row %d column %d - Expected %q, got %q", r, c, cell, tbl.rows[r][c])
+			}
+		}
+	}
+	if tbl.columnAlignment["Unit Price"] != Right || tbl.headerAlignment["Unit Price"] != Right {
+		t.Error("Expected Unit Price to be right aligned")
+	}
+
+	times, err := FromStructs([]struct {
+		At *time.Time
+		V  time.Time
+	}{{&created, created}})
+	if err != nil || times.rows[0][0] != "2021-03-04T05:06:07Z" || times.rows[0][1] != "2021-03-04T05:06:07Z" {
+		t.Errorf("Expected time fields in RFC 3339, got %q (%v)", times.rows, err)
+	}
+
+	shadowed, err := FromStructs([]struct {
+		testAddress
+		Name string
+	}{{testAddress{"inner", "Oslo"}, "outer"}})
+	if err != nil || strings.Join(shadowed.columns, ",") != "City,Name" || strings.Join(shadowed.rows[0], ",") != "Oslo,outer" {
+		t.Errorf("Expected the shallower Name to shadow the embedded one, got %q %q (%v)", shadowed.columns, shadowed.rows, err)
+	}
+	if _, err = FromStructs([]struct {
+		testAddress
+		Label string `tabula:"City"`
+	}{}); err != nil {
+		t.Error("Expected the shallower tagged field to win, got", err)
+	}
+	if _, err = FromStructs([]struct {
+		A string `tabula:"Same"`
+		B string `tabula:"Same"`
+	}{}); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+
+	nodes, err := FromStructs([]testNode{{Name: "a"}})
+	if err != nil || strings.Join(nodes.columns, ",") != "Name" || nodes.rows[0][0] != "a" {
+		t.Errorf("Expected a self-embedding struct to stop at its own type, got %q %q (%v)", nodes.columns, nodes.rows, err)
+	}
+
+	if _, err = FromStructs("nope"); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+	if _, err = FromStructs([]struct {
+		A int `tabula:",align=up"`
+	}{}); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+}
+
+func TestFromMaps(t *testing.T) {
+	records := []map[string]interface{}{
+		{"name": "a", "id": 1},
+		{"name": "b", "extra": true, "id": nil},
+	}
+	tbl := FromMaps(records, MapOptions{Placeholder: "-"})
+	expectedColumns := []string{"id", "name", "extra"}
+	for i, col := range expectedColumns {
+		if tbl.columns[i] != col {
+			t.Errorf("Expected column %d to be %q, got %q", i, col, tbl.columns[i])
+		}
+	}
+	if tbl.rows[0][2] != "-" || tbl.rows[1][0] != "" || tbl.rows[1][2] != "true" || tbl.rows[0][0] != "1" {
+		t.Errorf("Unexpected rows %q", tbl.rows)
+	}
+
+	tbl = FromMaps(records, MapOptions{SortColumns: true})
+	if tbl.columns[0] != "extra" || tbl.rows[0][0] != "" {
+		t.Errorf("Expected sorted columns, got %v %q", tbl.columns, tbl.rows)
+	}
+}
@@ -0,0 +1,113 @@
+package tables
+
+import "fmt"
+
+// HideColumns leaves the named columns out when the table is rendered or
+// exported. Their cells and settings are kept, so ShowColumns brings them back
+// unchanged.
+func (tbl *Table) HideColumns(colNames ...string) error {
+	if err := tbl.checkColumns(colNames); err != nil {
+		return err
+	}
+	if tbl.hidden == nil {
+		tbl.hidden = make(map[string]bool)
+	}
+	for _, colName := range colNames {
+		tbl.hidden[colName] = true
+	}
+	return nil
+}
+
+// ShowColumns undoes HideColumns for the named columns, or for every column if
+// no names are given.
+func (tbl *Table) ShowColumns(colNames ...string) {
+	if len(colNames) == 0 {
+		tbl.hidden = nil
+	}
+	for _, colName := range colNames {
+		delete(tbl.hidden, colName)
+	}
+}
+
+// ReorderColumns moves the named columns to the front of the table in the order
+// given. Columns left out keep their relative order after them.
+func (tbl *Table) ReorderColumns(colNames ...string) error {
+	if err := tbl.checkColumns(colNames); err != nil {
+		return err
+	}
+	order := append([]string{}, colNames...)
+	for _, colName := range tbl.columns {
+		if getSliceIndexString(colName, colNames) == -1 {
+			order = append(order, colName)
+		}
+	}
+	tbl.columns, tbl.rows = tbl.selectColumns(order)
+	return nil
+}
+
+// Project returns a new table holding only the named columns, in the order
+// given, with their alignment, widths and styling carried over.
+func (tbl *Table) Project(colNames ...string) (*Table, error) {
+	if err := tbl.checkColumns(colNames); err != nil {
+		return nil, err
+	}
+	projected := tbl.clone()
+	projected.columns, projected.rows = tbl.selectColumns(colNames)
+	projected.hidden = nil
+	projected.groupColumns = nil
+	for _, colName := range tbl.groupColumns {
+		if getSliceIndexString(colName, colNames) != -1 {
+			projected.groupColumns = append(projected.groupColumns, colName)
+		}
+	}
+	return projected, nil
+}
+
+// checkColumns returns an error naming the first unknown or repeated column.
+func (tbl *Table) checkColumns(colNames []string) error {
+	for i, colName := range colNames {
+		if getSliceIndexString(colName, tbl.columns) == -1 {
+			return fmt.Errorf("Unknown column %q", colName)
+		}
+		if getSliceIndexString(colName, colNames[:i]) != -1 {
+			return fmt.Errorf("Column %q listed twice", colName)
+		}
+	}
+	return nil
+}
+
+// selectColumns returns the named columns and copies of the rows holding only
+// their cells, in that order.
+func (tbl *Table) selectColumns(colNames []string) ([]string, [][]string) {
+	indexes := make([]int, len(colNames))
+	for i, colName := range colNames {
+		indexes[i] = getSliceIndexString(colName, tbl.columns)
+	}
+	rows := make([][]string, len(tbl.rows))
+	for r, row := range tbl.rows {
+		rows[r] = make([]string, len(indexes))
+		for i, col := range indexes {
+			rows[r][i] = row[col]
+		}
+	}
+	return append([]string{}, colNames...), rows
+}
+
+// view returns the table as it should be rendered: the table itself, or a
+// projection onto its visible columns if any are hidden.
+func (tbl *Table) view() *Table {
+	if len(tbl.hidden) == 0 {
+		return tbl
+	}
+	var visible []string
+	for _, colName := range tbl.columns {
+		if !tbl.hidden[colName] {
+			visible = append(visible, colName)
+		}
+	}
+	projected, _ := tbl.Project(visible...)
+	// Groups are worked out before projecting, so rows can still be grouped
+	// by hidden columns.
+	projected.groups = tbl.rowGroups()
+	return projected
+}
@@ -0,0 +1,179 @@
+package tables
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Quoting policies for CSVOptions.
+const (
+	QuoteMinimal    = iota // quote fields containing the delimiter, quotes, line breaks or leading spaces
+	QuoteAll               // quote every field
+	QuoteNonNumeric        // quote every field that doesn't parse as a number
+	QuoteNone              // never quote, failing on fields that would need it
+)
+
+// CSVOptions configures the CSV and TSV readers and writers.
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ',' for CSV and '\t' for TSV.
+	Comma rune
+	// Quoting is one of QuoteMinimal (the default), QuoteAll, QuoteNonNumeric or
+	// QuoteNone. It is ignored when reading.
+	Quoting int
+	// NoHeader leaves out the row of column names when writing. When reading,
+	// the first record is treated as data and the columns are taken from
+	// Columns, or named Col1, Col2, ... if Columns is empty.
+	NoHeader bool
+	// Columns names the columns of headerless input.
+	Columns []string
+	// UseCRLF terminates records with \r\n, as RFC 4180 specifies, instead of \n.
+	// Both line endings are accepted when reading.
+	UseCRLF bool
+}
+
+// FromCSV builds a table from comma separated values read from r. The first
+// record provides the column names unless opts.NoHeader is set, and every
+// following record is added with AddRow.
+func FromCSV(r io.Reader, opts CSVOptions) (*Table, error) {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	return readDelimited(r, opts)
+}
+
+// FromTSV builds a table from tab separated values read from r.
+func FromTSV(r io.Reader, opts CSVOptions) (*Table, error) {
+	if opts.Comma == 0 {
+		opts.Comma = '\t'
+	}
+	return readDelimited(r, opts)
+}
+
+func readDelimited(r io.Reader, opts CSVOptions) (tbl *Table, err error) {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.Comma
+	reader.FieldsPerRecord = -1
+
+	record, err := reader.Read()
+	if err == io.EOF {
+		if opts.NoHeader && len(opts.Columns) > 0 {
+			return NewTable(opts.Columns...), nil
+		}
+		return nil, errors.New("No header row found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !opts.NoHeader {
+		return fillFromCSV(reader, NewTable(record...))
+	}
+	columns := opts.Columns
+	if len(columns) == 0 {
+		for i := range record {
+			columns = append(columns, "Col"+strconv.Itoa(i+1))
+		}
+	}
+	tbl = NewTable(columns...)
+	if err = tbl.AddRow(record...); err != nil {
+		line, _ := reader.FieldPos(0)
+		return nil, fmt.Errorf("Line %d: %w", line, err)
+	}
+	return fillFromCSV(reader, tbl)
+}
+
+func fillFromCSV(reader *csv.Reader, tbl *Table) (*Table, error) {
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return tbl, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if err = tbl.AddRow(record...); err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("Line %d: %w", line, err)
+		}
+	}
+}
+
+// WriteCSV streams the table's columns and rows to w as comma separated values.
+func (tbl *Table) WriteCSV(w io.Writer, opts CSVOptions) error {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	return tbl.writeDelimited(w, opts)
+}
+
+// WriteTSV streams the table's columns and rows to w as tab separated values.
+func (tbl *Table) WriteTSV(w io.Writer, opts CSVOptions) error {
+	if opts.Comma == 0 {
+		opts.Comma = '\t'
+	}
+	return tbl.writeDelimited(w, opts)
+}
+
+func (tbl *Table) writeDelimited(w io.Writer, opts CSVOptions) (err error) {
+	tbl = tbl.view()
+	if opts.Comma == '"' || opts.Comma == '\r' || opts.Comma == '\n' || !utf8.ValidRune(opts.Comma) {
+		return fmt.Errorf("Invalid delimiter %q", opts.Comma)
+	}
+	tw := &tableWriter{w: w}
+	if !opts.NoHeader {
+		if err = writeRecord(tw, tbl.columns, opts); err != nil {
+			return
+		}
+	}
+	for _, row := range tbl.rows {
+		if err = writeRecord(tw, row, opts); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func writeRecord(tw *tableWriter, record []string, opts CSVOptions) error {
+	for i, field := range record {
+		if i > 0 {
+			tw.print(string(opts.Comma))
+		}
+		quote := false
+		switch opts.Quoting {
+		case QuoteAll:
+			quote = true
+		case QuoteNonNumeric:
+			_, err := strconv.ParseFloat(field, 64)
+			quote = err != nil || fieldNeedsQuotes(field, opts.Comma)
+		case QuoteNone:
+			if fieldNeedsQuotes(field, opts.Comma) {
+				return fmt.Errorf("Field %q needs quoting but quoting is disabled", field)
+			}
+		default:
+			quote = fieldNeedsQuotes(field, opts.Comma)
+		}
+		if quote {
+			tw.print(`"`, strings.ReplaceAll(field, `"`, `""`), `"`)
+		} else {
+			tw.print(field)
+		}
+	}
+	tw.print(ternary(opts.UseCRLF, "\r\n", "\n").(string))
+	return tw.err
+}
+
+// fieldNeedsQuotes reports whether field can only be written safely inside quotes.
+func fieldNeedsQuotes(field string, comma rune) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
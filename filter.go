@@ -0,0 +1,274 @@
+package tables
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Predicate reports whether a row belongs in a filtered table. The row maps
+// column names to cells.
+type Predicate func(row map[string]string) bool
+
+// Filter returns a new table with the same columns, alignment, borders and
+// styling as this one, holding only the rows that match every predicate.
+func (tbl *Table) Filter(predicates ...Predicate) *Table {
+	var keep []int
+	for i, row := range tbl.rows {
+		values := make(map[string]string, len(tbl.columns))
+		for col, colName := range tbl.columns {
+			values[colName] = StripANSI(row[col])
+		}
+		matched := true
+		for _, predicate := range predicates {
+			if !predicate(values) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			keep = append(keep, i)
+		}
+	}
+	filtered := tbl.clone()
+	filtered.permuteRows(keep)
+	return filtered
+}
+
+// Where filters the table with an expression such as
+//
+//	status = failed and (duration > 30 or host ~ "^db-")
+//
+// Comparisons take a column name, one of the operators =, !=, <, <=, >, >=, ~
+// (matches a regular expression) or !~, and a value. Numbers are compared by
+// value and anything else as text. Comparisons combine with and, or, not and
+// parentheses, and names or values containing spaces or operators can be
+// quoted with double or single quotes.
+func (tbl *Table) Where(expr string) (*Table, error) {
+	node, err := parseFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	for _, colName := range node.columns() {
+		if getSliceIndexString(colName, tbl.columns) == -1 {
+			return nil, fmt.Errorf("Unknown column %q in filter", colName)
+		}
+	}
+	return tbl.Filter(node.match), nil
+}
+
+// ParseFilter compiles a Where expression into a Predicate.
+func ParseFilter(expr string) (Predicate, error) {
+	node, err := parseFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	return node.match, nil
+}
+
+// filterNode is a parsed filter expression: a comparison, or an and, or or not
+// of other nodes.
+type filterNode struct {
+	op          string
+	column      string
+	value       string
+	pattern     *regexp.Regexp
+	left, right *filterNode
+}
+
+func (n *filterNode) match(row map[string]string) bool {
+	switch n.op {
+	case "and":
+		return n.left.match(row) && n.right.match(row)
+	case "or":
+		return n.left.match(row) || n.right.match(row)
+	case "not":
+		return !n.left.match(row)
+	case "~":
+		return n.pattern.MatchString(row[n.column])
+	case "!~":
+		return !n.pattern.MatchString(row[n.column])
+	}
+	c := compareValues(row[n.column], n.value)
+	switch n.op {
+	case "=", "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func (n *filterNode) columns() []string {
+	switch n.op {
+	case "and", "or":
+		return append(n.left.columns(), n.right.columns()...)
+	case "not":
+		return n.left.columns()
+	}
+	return []string{n.column}
+}
+
+// compareValues compares a cell to a filter value, by value if both are numbers.
+func compareValues(cell, value string) int {
+	a, _, okA := parseNumber(cell)
+	b, _, okB := parseNumber(value)
+	if okA && okB {
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.TrimSpace(cell), value)
+}
+
+// filterToken is a lexical token of a filter expression. Quoted tokens are never
+// keywords or operators.
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func parseFilter(expr string) (*filterNode, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("Unexpected %q in filter", p.tokens[p.pos].text)
+	}
+	return node, nil
+}
+
+func tokenizeFilter(expr string) (tokens []filterToken, err error) {
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("Unterminated quote in filter")
+			}
+			tokens = append(tokens, filterToken{text: expr[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case strings.IndexByte("=!<>~", c) >= 0:
+			op := string(c)
+			if i+1 < len(expr) && strings.IndexByte("=~", expr[i+1]) >= 0 {
+				op += string(expr[i+1])
+			}
+			switch op {
+			case "=", "==", "!=", "<", "<=", ">", ">=", "~", "!~":
+			default:
+				return nil, fmt.Errorf("Unknown operator %q in filter", op)
+			}
+			tokens = append(tokens, filterToken{text: op})
+			i += len(op)
+		default:
+			start := i
+			for i < len(expr) && strings.IndexByte(" \t\r\n()\"'=!<>~", expr[i]) == -1 {
+				i++
+			}
+			tokens = append(tokens, filterToken{text: expr[start:i]})
+		}
+	}
+	return
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *filterParser) parseOr() (*filterNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peekKeyword("or") {
+		p.pos++
+		var right *filterNode
+		if right, err = p.parseAnd(); err == nil {
+			left = &filterNode{op: "or", left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseAnd() (*filterNode, error) {
+	left, err := p.parseTerm()
+	for err == nil && p.peekKeyword("and") {
+		p.pos++
+		var right *filterNode
+		if right, err = p.parseTerm(); err == nil {
+			left = &filterNode{op: "and", left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseTerm() (*filterNode, error) {
+	switch {
+	case p.pos >= len(p.tokens):
+		return nil, fmt.Errorf("Unexpected end of filter")
+	case p.peekKeyword("not"):
+		p.pos++
+		node, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNode{op: "not", left: node}, nil
+	case p.peekKeyword("("):
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekKeyword(")") {
+			return nil, fmt.Errorf("Missing closing parenthesis in filter")
+		}
+		p.pos++
+		return node, nil
+	}
+
+	if p.pos+3 > len(p.tokens) {
+		return nil, fmt.Errorf("Incomplete comparison in filter")
+	}
+	column, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if op.quoted || strings.IndexByte("=!<>~", op.text[0]) == -1 {
+		return nil, fmt.Errorf("Expected an operator after %q in filter, got %q", column.text, op.text)
+	}
+	if !value.quoted && strings.IndexByte("()=!<>~", value.text[0]) >= 0 {
+		return nil, fmt.Errorf("Expected a value after %q in filter, got %q", op.text, value.text)
+	}
+	p.pos += 3
+	node := &filterNode{op: op.text, column: column.text, value: value.text}
+	if op.text == "~" || op.text == "!~" {
+		pattern, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern in filter: %w", err)
+		}
+		node.pattern = pattern
+	}
+	return node, nil
+}
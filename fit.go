@@ -0,0 +1,130 @@
+package tables
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// AutoWidth makes SetTargetWidth fit the table to the width of the terminal.
+const AutoWidth = -1
+
+// DefaultMinWidth specifies the narrowest a column is shrunk to when fitting a
+// table to its target width. Columns that are naturally narrower keep their width.
+var DefaultMinWidth = 4
+
+// TerminalWidth returns the width of the terminal w writes to, falling back to
+// the terminal on standard output and then to the COLUMNS environment variable.
+// It returns 0 if the width can't be determined.
+func TerminalWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if width := ttyWidth(f); width > 0 {
+			return width
+		}
+	}
+	if width := ttyWidth(os.Stdout); width > 0 {
+		return width
+	}
+	width, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return max(width, 0)
+}
+
+// SetTargetWidth sets the total width the rendered table should fit in, borders
+// and padding included. Columns that would make the table wider are shrunk and
+// their cells wrapped or truncated according to their Overflow. AutoWidth uses
+// the width of the terminal, and 0, the default, lets the table grow as wide
+// as its content.
+func (tbl *Table) SetTargetWidth(width int) {
+	tbl.targetWidth = width
+}
+
+// SetPriority sets how important it is to keep a column wide when fitting the
+// table to its target width. Columns with the lowest priority are shrunk first,
+// and columns of equal priority are shrunk in proportion to their width. All
+// columns start with priority 0.
+func (tbl *Table) SetPriority(colName string, priority int) {
+	tbl.priorities[colName] = priority
+}
+
+// fitWidths works out the column widths that make the table fit its target
+// width when written to w, storing them as limits for cellLines.
+func (tbl *Table) fitWidths(w io.Writer) {
+	tbl.fittedWidths = make(map[string]int)
+	target := tbl.targetWidth
+	if target == AutoWidth {
+		target = TerminalWidth(w)
+	}
+	if target <= 0 {
+		return
+	}
+
+	tbl.fillWidths()
+	available := target
+	if tbl.borders.showLeft {
+		available--
+	}
+	if tbl.borders.showRight {
+		available--
+	}
+	if tbl.borders.showCenter {
+		available -= len(tbl.columns) - 1
+	}
+	excess := -available
+	for _, colName := range tbl.columns {
+		excess += tbl.calcWidth(colName, true)
+		tbl.fittedWidths[colName] = tbl.columnWidths[colName]
+	}
+	if excess <= 0 {
+		return
+	}
+
+	// Shrink the lowest priority columns first, in proportion to how much each
+	// of them can give up.
+	var priorities []int
+	byPriority := make(map[int][]string)
+	for _, colName := range tbl.columns {
+		p := tbl.priorities[colName]
+		if _, ok := byPriority[p]; !ok {
+			priorities = append(priorities, p)
+		}
+		byPriority[p] = append(byPriority[p], colName)
+	}
+	sort.Ints(priorities)
+	for _, p := range priorities {
+		slack := make(map[string]int)
+		totalSlack := 0
+		for _, colName := range byPriority[p] {
+			width := tbl.fittedWidths[colName]
+			slack[colName] = width - min(width, DefaultMinWidth)
+			totalSlack += slack[colName]
+		}
+		if totalSlack == 0 {
+			continue
+		}
+		shrink := min(excess, totalSlack)
+		excess -= shrink
+		// Hand out the shrinkage proportionally, giving the rounding
+		// remainder to the columns with the most slack.
+		cols := append([]string{}, byPriority[p]...)
+		sort.SliceStable(cols, func(i, j int) bool { return slack[cols[i]] > slack[cols[j]] })
+		given := 0
+		for _, colName := range cols {
+			share := shrink * slack[colName] / totalSlack
+			tbl.fittedWidths[colName] -= share
+			given += share
+		}
+		for _, colName := range cols {
+			if given == shrink {
+				break
+			}
+			if tbl.fittedWidths[colName] > min(tbl.columnWidths[colName], DefaultMinWidth) {
+				tbl.fittedWidths[colName]--
+				given++
+			}
+		}
+		if excess == 0 {
+			break
+		}
+	}
+}
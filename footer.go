@@ -0,0 +1,88 @@
+package tables
+
+// SetFooter shows a literal value in the column's footer cell.
+func (tbl *Table) SetFooter(colName string, value string) {
+	tbl.footers[colName] = value
+	delete(tbl.footerAggregates, colName)
+}
+
+// SetFooterAggregate computes the column's footer cell from all of its cells when
+// the table is rendered, for example with Sum or Count.
+func (tbl *Table) SetFooterAggregate(colName string, aggregate AggregateFunc) {
+	tbl.footerAggregates[colName] = aggregate
+	delete(tbl.footers, colName)
+}
+
+// AlignFooter sets the alignment of the column's footer cell. Footer cells follow
+// the column's alignment until it is set.
+func (tbl *Table) AlignFooter(colName string, alignment int) {
+	tbl.footerAlignment[colName] = alignment
+}
+
+// SetFooterStyle sets the style of the footer row.
+func (tbl *Table) SetFooterStyle(style Style) {
+	tbl.footerStyle = style
+}
+
+// hasFooter reports whether any of the table's columns has a footer cell set.
+// Settings for hidden or projected away columns don't count.
+func (tbl *Table) hasFooter() bool {
+	for _, colName := range tbl.columns {
+		_, literal := tbl.footers[colName]
+		_, aggregate := tbl.footerAggregates[colName]
+		if literal || aggregate {
+			return true
+		}
+	}
+	return false
+}
+
+// footerCells returns the footer row, computing aggregates from the current rows.
+func (tbl *Table) footerCells() []string {
+	cells := make([]string, len(tbl.columns))
+	for i, colName := range tbl.columns {
+		if aggregate, ok := tbl.footerAggregates[colName]; ok {
+			cells[i] = aggregate(columnValues(tbl.rows, i))
+		} else {
+			cells[i] = tbl.footers[colName]
+		}
+	}
+	return cells
+}
+
+// footerAlignments returns the alignment of each footer cell, falling back to the
+// column alignment.
+func (tbl *Table) footerAlignments() map[string]int {
+	alignment := make(map[string]int)
+	for _, colName := range tbl.columns {
+		if align, ok := tbl.footerAlignment[colName]; ok {
+			alignment[colName] = align
+		} else {
+			alignment[colName] = tbl.columnAlignment[colName]
+		}
+	}
+	return alignment
+}
+
+func (tbl *Table) printFooter(tw *tableWriter) {
+	if !tbl.hasFooter() {
+		return
+	}
+	if tbl.borders.showFooter {
+		tbl.printRule(tw, tbl.borders.boldFooter)
+	}
+	tbl.printSummary(tw, tbl.footerCells(), tbl.footerStyle)
+}
+
+// printSummary prints a footer or subtotal row.
+func (tbl *Table) printSummary(tw *tableWriter, cells []string, style Style) {
+	alignment := tbl.footerAlignments()
+	styles := make([]Style, len(tbl.columns))
+	for i, colName := range tbl.columns {
+		if alignment[colName] == Decimal {
+			cells[i] = tbl.decimalText(cells[i], colName)
+		}
+		styles[i] = style
+	}
+	tbl.printLines(tw, cells, alignment, styles)
+}
@@ -0,0 +1,155 @@
+package tables
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	tbl := NewTable("Name", "Qty", "Note")
+	tbl.AddRow("apple", "3", "a|b")
+	tbl.AddRow("kiwi", "120", "line1\nline2")
+	tbl.Align("Qty", Right, true)
+	tbl.Align("Note", Center, false)
+
+	out, err := tbl.Markdown()
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := "| Name  | Qty |      Note      |\n" +
+		"| :---- | --: | :------------: |\n" +
+		"| apple |   3 |      a\\|b      |\n" +
+		"| kiwi  | 120 | line1<br>line2 |\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tbl := NewTable("Name", "Price")
+	tbl.AddRow("Widget, large", "9.99")
+	tbl.AddRow(`The "best"`, "10")
+
+	var sb strings.Builder
+	if err := tbl.WriteCSV(&sb, CSVOptions{}); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := "Name,Price\n\"Widget, large\",9.99\n\"The \"\"best\"\"\",10\n"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	if err := tbl.WriteCSV(&sb, CSVOptions{Quoting: QuoteNonNumeric, NoHeader: true, UseCRLF: true}); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected = "\"Widget, large\",9.99\r\n\"The \"\"best\"\"\",10\r\n"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	if err := tbl.WriteTSV(&sb, CSVOptions{Quoting: QuoteAll}); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected = "\"Name\"\t\"Price\"\n\"Widget, large\"\t\"9.99\"\n\"The \"\"best\"\"\"\t\"10\"\n"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+
+	if err := tbl.WriteCSV(&sb, CSVOptions{Quoting: QuoteNone}); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+}
+
+func TestFromCSV(t *testing.T) {
+	tbl, err := FromCSV(strings.NewReader("Name,Qty\n\"a, b\",1\r\nc,2\n"), CSVOptions{})
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if len(tbl.columns) != 2 || tbl.columns[0] != "Name" || len(tbl.rows) != 2 || tbl.rows[0][0] != "a, b" {
+		t.Errorf("Unexpected table contents: %v %v", tbl.columns, tbl.rows)
+	}
+
+	tbl, err = FromTSV(strings.NewReader("x\t1\ny\t2\n"), CSVOptions{NoHeader: true})
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if tbl.columns[1] != "Col2" || len(tbl.rows) != 2 || tbl.rows[1][1] != "2" {
+		t.Errorf("Unexpected table contents: %v %v", tbl.columns, tbl.rows)
+	}
+
+	_, err = FromCSV(strings.NewReader("a,b\n1,2\n3\n"), CSVOptions{})
+	if err == nil || !strings.HasPrefix(err.Error(), "Line 3:") {
+		t.Error("Expected error on line 3, got", err)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	tbl := NewTable("Name", "Qty")
+	tbl.AddRow("<b>&co</b>", "3")
+	tbl.Align("Qty", Right, false)
+	tbl.SetBorder(Center, true, true)
+	tbl.SetBorder(Top, true, false)
+
+	out, err := tbl.HTML(HTMLOptions{Class: "report", AlignmentClasses: true})
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := `<table class="report">
+  <thead>
+    <tr>
+      <th class="align-left">Name</th>
+      <th class="align-left">Qty</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <td class="align-left">&lt;b&gt;&amp;co&lt;/b&gt;</td>
+      <td class="align-right">3</td>
+    </tr>
+  </tbody>
+</table>
+`
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	out, _ = tbl.HTML(HTMLOptions{Borders: true})
+	if !strings.Contains(out, `<table style="border-collapse: collapse; border-top: 1px solid;">`) ||
+		!strings.Contains(out, `<td style="text-align: right; border-left: 2px solid;">3</td>`) {
+		t.Errorf("Borders not mapped to CSS, got\n%s", out)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	tbl := NewTable("Zone", "Count", "Ratio")
+	tbl.AddRow("b\"x", "12", "0.5")
+	tbl.AddRow("a", "007", "-1e3")
+
+	var sb strings.Builder
+	if err := tbl.WriteJSON(&sb, JSONOptions{TypeHints: true}); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := `[{"Zone":"b\"x","Count":12,"Ratio":0.5},{"Zone":"a","Count":"007","Ratio":-1e3}]` + "\n"
+	if sb.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, sb.String())
+	}
+
+	sb.Reset()
+	if err := tbl.WriteNDJSON(&sb, JSONOptions{Arrays: true}); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected = "[\"Zone\",\"Count\",\"Ratio\"]\n[\"b\\\"x\",\"12\",\"0.5\"]\n[\"a\",\"007\",\"-1e3\"]\n"
+	if sb.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, sb.String())
+	}
+
+	sb.Reset()
+	tbl.WriteJSON(&sb, JSONOptions{Indent: "  "})
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal([]byte(sb.String()), &decoded); err != nil || len(decoded) != 2 || decoded[1]["Count"] != "007" {
+		t.Errorf("Indented output did not round trip: %v\n%s", err, sb.String())
+	}
+}
@@ -0,0 +1,181 @@
+package tables
+
+import "strings"
+
+// rowGroup is a run of rows sharing the same values in the grouping columns,
+// with the label shown above them.
+type rowGroup struct {
+	label string
+	rows  []int
+}
+
+// GroupBy groups rows by their values in the named columns when the table is
+// rendered. Groups appear in the order their first row was added, each under a
+// full width row naming its values, and the Horizontal border is drawn only
+// between groups. Calling GroupBy with no columns removes the grouping.
+// Grouping only affects Print and the other terminal renderers; exports keep
+// rows in their own order.
+func (tbl *Table) GroupBy(colNames ...string) error {
+	if err := tbl.checkColumns(colNames); err != nil {
+		return err
+	}
+	tbl.groupColumns = append([]string{}, colNames...)
+	return nil
+}
+
+// SetSubtotal adds a subtotal row to the end of every group, with the column's
+// cell computed from the group's cells, for example with Sum. Subtotal cells
+// are aligned like footer cells.
+func (tbl *Table) SetSubtotal(colName string, aggregate AggregateFunc) {
+	tbl.subtotals[colName] = aggregate
+}
+
+// SetGroupStyle sets the style of the group header rows.
+func (tbl *Table) SetGroupStyle(style Style) {
+	tbl.groupStyle = style
+}
+
+// SetSubtotalStyle sets the style of the subtotal rows.
+func (tbl *Table) SetSubtotalStyle(style Style) {
+	tbl.subtotalStyle = style
+}
+
+// rowGroups returns the groups to render, or nil if the table isn't grouped.
+func (tbl *Table) rowGroups() []rowGroup {
+	if tbl.groups != nil || len(tbl.groupColumns) == 0 {
+		return tbl.groups
+	}
+	indexes := make([]int, len(tbl.groupColumns))
+	for i, colName := range tbl.groupColumns {
+		indexes[i] = getSliceIndexString(colName, tbl.columns)
+	}
+	groups := []rowGroup{}
+	byLabel := make(map[string]int)
+	for r, row := range tbl.rows {
+		parts := make([]string, len(indexes))
+		for i, col := range indexes {
+			parts[i] = tbl.groupColumns[i] + ": " + StripANSI(row[col])
+		}
+		label := strings.ReplaceAll(strings.Join(parts, ", "), "\n", " ")
+		g, ok := byLabel[label]
+		if !ok {
+			g = len(groups)
+			byLabel[label] = g
+			groups = append(groups, rowGroup{label: label})
+		}
+		groups[g].rows = append(groups[g].rows, r)
+	}
+	return groups
+}
+
+// hasSubtotals reports whether any of the table's columns has a subtotal set.
+func (tbl *Table) hasSubtotals() bool {
+	for _, colName := range tbl.columns {
+		if _, ok := tbl.subtotals[colName]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// subtotalCells returns the subtotal row of a group.
+func (tbl *Table) subtotalCells(group rowGroup) []string {
+	cells := make([]string, len(tbl.columns))
+	for i, colName := range tbl.columns {
+		if aggregate, ok := tbl.subtotals[colName]; ok {
+			values := make([]string, len(group.rows))
+			for j, r := range group.rows {
+				values[j] = tbl.rows[r][i]
+			}
+			cells[i] = aggregate(values)
+		}
+	}
+	return cells
+}
+
+// summaryRows returns the footer and subtotal rows, all of which are aligned
+// by footerAlignments.
+func (tbl *Table) summaryRows() (rows [][]string) {
+	if tbl.hasFooter() {
+		rows = append(rows, tbl.footerCells())
+	}
+	if tbl.hasSubtotals() {
+		for _, group := range tbl.rowGroups() {
+			rows = append(rows, tbl.subtotalCells(group))
+		}
+	}
+	return
+}
+
+// groupWidth returns the room a group header has for its label: the width of
+// the table inside its left and right borders and padding.
+func (tbl *Table) groupWidth() int {
+	last := len(tbl.columns) - 1
+	width := -tbl.padding(true, 0) - tbl.padding(false, last)
+	for _, colName := range tbl.columns {
+		width += tbl.calcWidth(colName, true)
+	}
+	if tbl.borders.showCenter {
+		width += last
+	}
+	return width
+}
+
+// fillGroupWidths widens columns, starting from the last, until the widest group
+// label fits. Columns don't grow past their maximum or fitted width, so labels
+// are only truncated when one of those forbids the room.
+func (tbl *Table) fillGroupWidths() {
+	excess := 0
+	for _, group := range tbl.rowGroups() {
+		excess = max(excess, tbl.stringWidth(group.label)-tbl.groupWidth())
+	}
+	for col := len(tbl.columns) - 1; col >= 0 && excess > 0; col-- {
+		colName := tbl.columns[col]
+		grow := excess
+		if limit := tbl.widthLimit(colName); limit > 0 {
+			grow = min(grow, limit-tbl.columnWidths[colName])
+		}
+		if grow > 0 {
+			tbl.columnWidths[colName] += grow
+			excess -= grow
+		}
+	}
+}
+
+func (tbl *Table) printGroups(tw *tableWriter, groups []rowGroup) {
+	for g, group := range groups {
+		if g > 0 {
+			tbl.printHorizontal(tw)
+		}
+		tbl.printGroupHeader(tw, group.label)
+		for _, r := range group.rows {
+			tbl.printCells(tw, r)
+		}
+		if tbl.hasSubtotals() {
+			tbl.printSummary(tw, tbl.subtotalCells(group), tbl.subtotalStyle)
+		}
+	}
+}
+
+// printGroupHeader prints a row spanning every column, truncating the label if
+// column limits kept fillGroupWidths from making room for it.
+func (tbl *Table) printGroupHeader(tw *tableWriter, label string) {
+	last := len(tbl.columns) - 1
+	width := tbl.groupWidth()
+	if tbl.stringWidth(label) > width {
+		label = tbl.truncate(label, width, TruncateEnd)
+	}
+
+	if tbl.borders.showLeft {
+		tw.print(ternary(tbl.borders.boldLeft, "┃", "│").(string))
+	}
+	tw.print(
+		strings.Repeat(" ", tbl.padding(true, 0)),
+		tbl.alignCell(tbl.groupStyle.apply(label, tw.profile), width, Left),
+		strings.Repeat(" ", tbl.padding(false, last)),
+	)
+	if tbl.borders.showRight {
+		tw.print(ternary(tbl.borders.boldRight, "┃", "│").(string))
+	}
+	tw.print("\n")
+}
@@ -20,34 +20,90 @@ func max(val ...int) (max int) {
 	return
 }
 
-func (tbl *Table) FillWidths() {
+func min(val ...int) (min int) {
+	min = val[0]
+	for i := 1; i < len(val); i++ {
+		if val[i] < min {
+			min = val[i]
+		}
+	}
+	return
+}
+
+// fillWidths sizes every column to its widest line, header included, once cells
+// have been wrapped to the column's maximum width.
+func (tbl *Table) fillWidths() {
+	tbl.fillDecimalWidths()
+	for _, colName := range tbl.columns {
+		tbl.columnWidths[colName] = tbl.linesWidth(tbl.cellLines(colName, colName))
+	}
 	for _, row := range tbl.rows {
 		for col, cell := range row {
-			tbl.columnWidths[tbl.columns[col]] = max(tbl.columnWidths[tbl.columns[col]], len(cell))
+			colName := tbl.columns[col]
+			cell = tbl.decimalText(cell, colName)
+			tbl.columnWidths[colName] = max(tbl.columnWidths[colName], tbl.linesWidth(tbl.cellLines(cell, colName)))
+		}
+	}
+	alignment := tbl.footerAlignments()
+	for _, row := range tbl.summaryRows() {
+		for col, cell := range row {
+			colName := tbl.columns[col]
+			if alignment[colName] == Decimal {
+				cell = tbl.decimalText(cell, colName)
+			}
+			tbl.columnWidths[colName] = max(tbl.columnWidths[colName], tbl.linesWidth(tbl.cellLines(cell, colName)))
 		}
 	}
+	tbl.fillGroupWidths()
 }
 
+func (tbl *Table) linesWidth(lines []string) (width int) {
+	for _, line := range lines {
+		width = max(width, tbl.stringWidth(line))
+	}
+	return
+}
+
+// FillWidths measures every column. Rendering does this itself, so it is only
+// needed to inspect widths before the table has been printed.
+func (tbl *Table) FillWidths() {
+	tbl.fillWidths()
+}
+
+// CalcWidth returns the width of the column, optionally including its padding.
+// With verbose set it also prints how the width was made up.
 func (tbl *Table) CalcWidth(column string, pad bool, verbose bool) (calcWidth int, debug debugCol) {
 	i := getSliceIndexString(column, tbl.columns)
-	debug.ColName = tbl.columns[i]
-	debug.Chars = tbl.CharWidth(debug.ColName)
-	debug.PaddingBefore = tbl.Padding(true, i)
-	debug.PaddingAfter = tbl.Padding(false, i)
+	debug.ColName = column
+	debug.Chars = tbl.CharWidth(column)
+	debug.PaddingBefore = tbl.padding(true, i)
+	debug.PaddingAfter = tbl.padding(false, i)
 
-	calcWidth = tbl.CharWidth(debug.ColName)
-	if pad {
-		calcWidth = tbl.columnWidths[debug.ColName] + tbl.Padding(true, i) + tbl.Padding(false, i)
-	}
+	calcWidth = tbl.calcWidth(column, pad)
 	if verbose {
 		center, _ := tbl.GetBorder(Center)
 		fmt.Printf("Column %s(%d) > width: %d + %d + %d =  %d (%t)\n", debug.ColName, i, debug.Chars, debug.PaddingBefore, debug.PaddingAfter, calcWidth, center)
 	}
+	return
+}
 
+// calcWidth returns the width of the column's content, optionally including the
+// padding around it.
+func (tbl *Table) calcWidth(column string, pad bool) (calcWidth int) {
+	i := getSliceIndexString(column, tbl.columns)
+	calcWidth = tbl.CharWidth(column)
+	if pad {
+		calcWidth += tbl.padding(true, i) + tbl.padding(false, i)
+	}
 	return
 }
 
+// Padding returns the number of spaces before or after the content of a column.
 func (tbl *Table) Padding(before bool, colIndex int) int {
+	return tbl.padding(before, colIndex)
+}
+
+func (tbl *Table) padding(before bool, colIndex int) int {
 	if colIndex == 0 { // First column
 		if (before && !tbl.borders.showLeft) || (!before && !tbl.borders.showCenter) { // spacing before content with no left border or spacing after content with no center border
 			return 0
@@ -93,10 +149,73 @@ func (tbl *Table) GetBorder(border int) (bool, bool) {
 		return tbl.borders.showCenter, tbl.borders.boldCenter
 	case Right:
 		return tbl.borders.showRight, tbl.borders.boldRight
+	case Footer:
+		return tbl.borders.showFooter, tbl.borders.boldFooter
 	}
 	return false, false
 }
 
+// clone returns a copy of the table, sharing no rows or settings with it.
+func (tbl *Table) clone() *Table {
+	c := *tbl
+	c.columns = append([]string{}, tbl.columns...)
+	c.rows = make([][]string, len(tbl.rows))
+	for i, row := range tbl.rows {
+		c.rows[i] = append([]string{}, row...)
+	}
+	c.columnAlignment = copyMap(tbl.columnAlignment)
+	c.headerAlignment = copyMap(tbl.headerAlignment)
+	c.verticalAlignment = copyMap(tbl.verticalAlignment)
+	c.columnWidths = copyMap(tbl.columnWidths)
+	c.maxWidths = copyMap(tbl.maxWidths)
+	c.priorities = copyMap(tbl.priorities)
+	c.footerAlignment = copyMap(tbl.footerAlignment)
+	c.columnStyles = make(map[string]Style)
+	for k, v := range tbl.columnStyles {
+		c.columnStyles[k] = v
+	}
+	c.rowStyles = make(map[int]Style)
+	for k, v := range tbl.rowStyles {
+		c.rowStyles[k] = v
+	}
+	c.cellStyles = make(map[cellRef]Style)
+	for k, v := range tbl.cellStyles {
+		c.cellStyles[k] = v
+	}
+	c.overflow = make(map[string]Overflow)
+	for k, v := range tbl.overflow {
+		c.overflow[k] = v
+	}
+	c.footers = make(map[string]string)
+	for k, v := range tbl.footers {
+		c.footers[k] = v
+	}
+	c.footerAggregates = make(map[string]AggregateFunc)
+	for k, v := range tbl.footerAggregates {
+		c.footerAggregates[k] = v
+	}
+	c.groupColumns = append([]string{}, tbl.groupColumns...)
+	c.subtotals = make(map[string]AggregateFunc)
+	for k, v := range tbl.subtotals {
+		c.subtotals[k] = v
+	}
+	if tbl.hidden != nil {
+		c.hidden = make(map[string]bool)
+		for k, v := range tbl.hidden {
+			c.hidden[k] = v
+		}
+	}
+	return &c
+}
+
+func copyMap(m map[string]int) map[string]int {
+	c := make(map[string]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
 // includesInt checks if the integer is in the array, returning the index if it is, or -1 if it isn't
 func getSliceIndexInt(needle int, haystack []int) (index int) {
 	var val int
@@ -0,0 +1,114 @@
+package tables
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLOptions configures WriteHTML.
+type HTMLOptions struct {
+	// Class is set as the class attribute of the <table> element.
+	Class string
+	// AlignmentClasses emits alignment as align-left, align-center,
+	// align-right and align-decimal classes instead of inline text-align
+	// styles, which render Decimal columns right aligned.
+	AlignmentClasses bool
+	// Borders maps the table's border configuration onto inline CSS borders,
+	// using 2px lines for bold borders and 1px lines otherwise.
+	Borders bool
+}
+
+// WriteHTML renders the table to w as <table> markup with a <thead> for the
+// column names, a <tbody> for the rows and a <tfoot> for the footer, if the
+// table has one. All cell text is HTML-escaped.
+func (tbl *Table) WriteHTML(w io.Writer, opts HTMLOptions) error {
+	tbl = tbl.view()
+	tw := &tableWriter{w: w}
+	tw.print("<table")
+	if opts.Class != "" {
+		tw.print(` class="`, html.EscapeString(opts.Class), `"`)
+	}
+	if opts.Borders {
+		tw.print(` style="border-collapse: collapse;`)
+		if tbl.borders.showTop {
+			tw.print(" border-top: ", cssBorder(tbl.borders.boldTop), ";")
+		}
+		if tbl.borders.showBottom {
+			tw.print(" border-bottom: ", cssBorder(tbl.borders.boldBottom), ";")
+		}
+		if tbl.borders.showLeft {
+			tw.print(" border-left: ", cssBorder(tbl.borders.boldLeft), ";")
+		}
+		if tbl.borders.showRight {
+			tw.print(" border-right: ", cssBorder(tbl.borders.boldRight), ";")
+		}
+		tw.print(`"`)
+	}
+	tw.print(">\n  <thead>\n")
+	tbl.printHTMLRow(tw, "th", -1, tbl.columns, tbl.headerAlignment, opts)
+	tw.print("  </thead>\n  <tbody>\n")
+	for i, row := range tbl.rows {
+		tbl.printHTMLRow(tw, "td", i, row, tbl.columnAlignment, opts)
+	}
+	tw.print("  </tbody>\n")
+	if tbl.hasFooter() {
+		tw.print("  <tfoot>\n")
+		tbl.printHTMLRow(tw, "td", -2, tbl.footerCells(), tbl.footerAlignments(), opts)
+		tw.print("  </tfoot>\n")
+	}
+	tw.print("</table>\n")
+	return tw.err
+}
+
+// HTML returns the table as <table> markup.
+func (tbl *Table) HTML(opts HTMLOptions) (string, error) {
+	var sb strings.Builder
+	err := tbl.WriteHTML(&sb, opts)
+	return sb.String(), err
+}
+
+// printHTMLRow writes a single <tr>. rowNum is -1 for the header row and -2 for
+// the footer row.
+func (tbl *Table) printHTMLRow(tw *tableWriter, tag string, rowNum int, cells []string, alignment map[string]int, opts HTMLOptions) {
+	tw.print("    <tr>\n")
+	for i, cell := range cells {
+		align := strings.ToLower(GetAlignment(alignment[tbl.columns[i]]))
+		if align == "" {
+			align = "left"
+		} else if align == "decimal" && !opts.AlignmentClasses {
+			align = "right"
+		}
+		tw.print("      <", tag)
+		if opts.AlignmentClasses {
+			tw.print(` class="align-`, align, `"`)
+		}
+		var style []string
+		if !opts.AlignmentClasses {
+			style = append(style, "text-align: "+align+";")
+		}
+		if opts.Borders {
+			if i > 0 && tbl.borders.showCenter {
+				style = append(style, "border-left: "+cssBorder(tbl.borders.boldCenter)+";")
+			}
+			if rowNum == -1 && tbl.borders.showHeader {
+				style = append(style, "border-bottom: "+cssBorder(tbl.borders.boldHeader)+";")
+			}
+			if rowNum > 0 && tbl.borders.showHorizontal {
+				style = append(style, "border-top: "+cssBorder(tbl.borders.boldHorizontal)+";")
+			}
+			if rowNum == -2 && tbl.borders.showFooter {
+				style = append(style, "border-top: "+cssBorder(tbl.borders.boldFooter)+";")
+			}
+		}
+		if len(style) > 0 {
+			tw.print(` style="`, strings.Join(style, " "), `"`)
+		}
+		tw.print(">", strings.ReplaceAll(html.EscapeString(cell), "\n", "<br>"), "</", tag, ">\n")
+	}
+	tw.print("    </tr>\n")
+}
+
+func cssBorder(bold bool) string {
+	return ternary(bold, "2px solid", "1px solid").(string)
+}
@@ -0,0 +1,101 @@
+package tables
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// jsonNumber matches cells that are valid JSON numbers as they stand.
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// JSONOptions configures WriteJSON and WriteNDJSON.
+type JSONOptions struct {
+	// Arrays emits each row as an array of cells in column order rather than
+	// an object keyed by column name. The column names are written first as
+	// their own array unless NoHeader is set.
+	Arrays bool
+	// NoHeader leaves out the column names array in Arrays mode.
+	NoHeader bool
+	// TypeHints emits numeric cells as JSON numbers instead of strings.
+	TypeHints bool
+	// Indent, if not empty, pretty-prints WriteJSON output. It is ignored by
+	// WriteNDJSON.
+	Indent string
+}
+
+// WriteJSON writes the table to w as a single JSON array with one element per row.
+func (tbl *Table) WriteJSON(w io.Writer, opts JSONOptions) error {
+	tw := &tableWriter{w: w}
+	records := tbl.jsonRecords(opts)
+	tw.print("[")
+	for i, record := range records {
+		tw.print(ternary(i > 0, ",", "").(string))
+		if opts.Indent != "" {
+			tw.print("\n", opts.Indent, strings.ReplaceAll(record, "\n", "\n"+opts.Indent))
+		} else {
+			tw.print(record)
+		}
+	}
+	tw.print(ternary(opts.Indent != "" && len(records) > 0, "\n]\n", "]\n").(string))
+	return tw.err
+}
+
+// WriteNDJSON writes the table to w as newline-delimited JSON, one row per line.
+func (tbl *Table) WriteNDJSON(w io.Writer, opts JSONOptions) error {
+	tw := &tableWriter{w: w}
+	opts.Indent = ""
+	for _, record := range tbl.jsonRecords(opts) {
+		tw.print(record, "\n")
+	}
+	return tw.err
+}
+
+// jsonRecords encodes every row, and the header in Arrays mode, as a JSON
+// value. Objects are built by hand so their keys keep the column order.
+func (tbl *Table) jsonRecords(opts JSONOptions) (records []string) {
+	tbl = tbl.view()
+	sep, open, close := ",", "", ""
+	if opts.Indent != "" {
+		sep, open, close = ",\n"+opts.Indent, "\n"+opts.Indent, "\n"
+	}
+	if opts.Arrays && !opts.NoHeader {
+		records = append(records, jsonArray(tbl.columns, JSONOptions{}, sep, open, close))
+	}
+	for _, row := range tbl.rows {
+		if opts.Arrays {
+			records = append(records, jsonArray(row, opts, sep, open, close))
+			continue
+		}
+		var sb strings.Builder
+		sb.WriteString("{" + open)
+		for i, cell := range row {
+			if i > 0 {
+				sb.WriteString(sep)
+			}
+			sb.WriteString(jsonValue(tbl.columns[i], JSONOptions{}))
+			sb.WriteString(ternary(opts.Indent != "", ": ", ":").(string))
+			sb.WriteString(jsonValue(cell, opts))
+		}
+		sb.WriteString(close + "}")
+		records = append(records, sb.String())
+	}
+	return
+}
+
+func jsonArray(cells []string, opts JSONOptions, sep, open, close string) string {
+	values := make([]string, len(cells))
+	for i, cell := range cells {
+		values[i] = jsonValue(cell, opts)
+	}
+	return "[" + open + strings.Join(values, sep) + close + "]"
+}
+
+func jsonValue(cell string, opts JSONOptions) string {
+	if opts.TypeHints && jsonNumber.MatchString(cell) {
+		return cell
+	}
+	encoded, _ := json.Marshal(cell)
+	return string(encoded)
+}
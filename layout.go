@@ -0,0 +1,254 @@
+package tables
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Overflow selects how cells wider than their column's maximum width are laid out.
+type Overflow int
+
+const (
+	WrapWord       Overflow = iota // wrap between words, breaking words only when they don't fit on a line
+	WrapChar                       // wrap at the column width regardless of words
+	TruncateEnd                    // cut the end of the text off, replacing it with the truncation marker
+	TruncateStart                  // cut the start of the text off
+	TruncateMiddle                 // cut the middle of the text out, keeping both ends
+)
+
+// DefaultTruncationMarker is shown in place of truncated text unless a table sets
+// its own with SetTruncationMarker.
+var DefaultTruncationMarker = "…"
+
+// SetMaxWidth limits the content width of a column. Wider cells, and the header,
+// are laid out according to the column's Overflow. A width of 0 removes the limit.
+func (tbl *Table) SetMaxWidth(colName string, width int) {
+	tbl.maxWidths[colName] = width
+}
+
+// SetOverflow sets how cells exceeding the column's maximum width are laid out,
+// wrapping them onto more lines or truncating them. Columns wrap between words by
+// default.
+func (tbl *Table) SetOverflow(colName string, overflow Overflow) {
+	tbl.overflow[colName] = overflow
+}
+
+// SetTruncationMarker sets the text shown in place of truncated text, such as
+// "..." for plain ASCII output. An empty marker truncates silently.
+func (tbl *Table) SetTruncationMarker(marker string) {
+	tbl.truncationMarker = &marker
+}
+
+// cellLines lays out a cell's text as the physical lines it is printed on: one
+// per line of a multi-line cell, each wrapped or truncated to the column's width
+// limit.
+func (tbl *Table) cellLines(text string, colName string) []string {
+	width := tbl.widthLimit(colName)
+	if !strings.ContainsAny(text, "\r\n") && (width <= 0 || tbl.stringWidth(text) <= width) {
+		return []string{text}
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		switch overflow := tbl.overflow[colName]; {
+		case width <= 0 || tbl.stringWidth(line) <= width:
+			lines = append(lines, line)
+		case overflow == TruncateEnd || overflow == TruncateStart || overflow == TruncateMiddle:
+			lines = append(lines, tbl.truncate(line, width, overflow))
+		case overflow == WrapChar:
+			lines = append(lines, tbl.wrapChars(line, width)...)
+		default:
+			lines = append(lines, tbl.wrapWords(line, width)...)
+		}
+	}
+	return carryEscapes(lines)
+}
+
+// VerticalAlign sets where cells in the column sit when other cells in their row
+// take more lines: Top (the default), Center or Bottom.
+func (tbl *Table) VerticalAlign(colName string, alignment int) {
+	tbl.verticalAlignment[colName] = alignment
+}
+
+// widthLimit returns the widest a column's content may be, from its maximum
+// width and the width it was fitted to, or 0 if it is unlimited.
+func (tbl *Table) widthLimit(colName string) int {
+	limit := tbl.maxWidths[colName]
+	if fitted := tbl.fittedWidths[colName]; fitted > 0 && (limit <= 0 || fitted < limit) {
+		limit = fitted
+	}
+	return limit
+}
+
+// wrapWords breaks text into lines of at most width, between words where it can.
+func (tbl *Table) wrapWords(text string, width int) (lines []string) {
+	line, lineWidth := "", 0
+	for _, word := range strings.Fields(text) {
+		wordWidth := tbl.stringWidth(word)
+		if lineWidth > 0 && lineWidth+1+wordWidth <= width {
+			line += " " + word
+			lineWidth += 1 + wordWidth
+			continue
+		}
+		if lineWidth > 0 {
+			lines = append(lines, line)
+		}
+		if wordWidth > width {
+			chunks := tbl.wrapChars(word, width)
+			lines = append(lines, chunks[:len(chunks)-1]...)
+			word = chunks[len(chunks)-1]
+			wordWidth = tbl.stringWidth(word)
+		}
+		line, lineWidth = word, wordWidth
+	}
+	return append(lines, line)
+}
+
+// wrapChars breaks text into lines of at most width, keeping grapheme clusters
+// whole. A cluster wider than width gets a line of its own.
+func (tbl *Table) wrapChars(text string, width int) (lines []string) {
+	line, lineWidth := "", 0
+	for _, cluster := range tbl.clusters(text) {
+		clusterWidth := tbl.stringWidth(cluster)
+		if lineWidth > 0 && lineWidth+clusterWidth > width {
+			lines = append(lines, line)
+			line, lineWidth = "", 0
+		}
+		line += cluster
+		lineWidth += clusterWidth
+	}
+	return append(lines, line)
+}
+
+// truncate shortens text to at most width, cutting whole grapheme clusters from
+// the side selected by overflow and putting the truncation marker in their
+// place. Escape sequences in the cut text are kept so colors and hyperlinks are
+// still closed properly.
+func (tbl *Table) truncate(text string, width int, overflow Overflow) string {
+	marker := DefaultTruncationMarker
+	if tbl.truncationMarker != nil {
+		marker = *tbl.truncationMarker
+	}
+	markerWidth := tbl.stringWidth(marker)
+	if markerWidth >= width {
+		marker, markerWidth = "", 0
+	}
+	clusters := tbl.clusters(text)
+	keep := width - markerWidth
+
+	// Take clusters from the start, the end, or alternately from both ends
+	// until the width allowance runs out.
+	head, tail := 0, len(clusters)
+	headWidth, tailWidth := 0, 0
+	for head < tail {
+		fromHead := overflow == TruncateEnd || (overflow == TruncateMiddle && headWidth <= tailWidth)
+		i := ternary(fromHead, head, tail-1).(int)
+		w := tbl.stringWidth(clusters[i])
+		if headWidth+tailWidth+w > keep {
+			break
+		}
+		if fromHead {
+			head++
+			headWidth += w
+		} else {
+			tail--
+			tailWidth += w
+		}
+	}
+
+	var sb strings.Builder
+	for _, cluster := range clusters[:head] {
+		sb.WriteString(cluster)
+	}
+	for _, cluster := range clusters[head:tail] {
+		sb.WriteString(escapesOf(cluster))
+	}
+	sb.WriteString(marker)
+	for _, cluster := range clusters[tail:] {
+		sb.WriteString(cluster)
+	}
+	return sb.String()
+}
+
+// escapesOf returns the escape sequences in s with the text between them removed.
+func escapesOf(s string) string {
+	if !hasEscapes(s) {
+		return ""
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			sb.WriteString(s[i : i+n])
+			i += n
+		} else {
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// clusters splits text into the units it can be broken between: grapheme
+// clusters, as far as the table's WidthFunc can tell, with escape sequences
+// attached to the cluster before them.
+func (tbl *Table) clusters(text string) (clusters []string) {
+	current, pending := "", ""
+	for i := 0; i < len(text); {
+		if n := escapeLen(text[i:]); n > 0 {
+			if current != "" {
+				current += text[i : i+n]
+			} else {
+				pending += text[i : i+n]
+			}
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		next := text[i : i+size]
+		if current != "" && tbl.stringWidth(current+next) <= tbl.stringWidth(current) {
+			current += next
+		} else {
+			if current != "" {
+				clusters = append(clusters, current)
+			}
+			current = pending + next
+			pending = ""
+		}
+		i += size
+	}
+	if current != "" || pending != "" {
+		clusters = append(clusters, pending+current)
+	}
+	return
+}
+
+// carryEscapes makes every line stand on its own when a pre-colored cell is
+// split: SGR sequences still in effect at the end of a line are reset there and
+// repeated at the start of the next line.
+func carryEscapes(lines []string) []string {
+	active := ""
+	for i, line := range lines {
+		if active == "" && !hasEscapes(line) {
+			continue
+		}
+		prefix := active
+		for j := 0; j < len(line); {
+			n := escapeLen(line[j:])
+			if n == 0 {
+				j++
+				continue
+			}
+			if seq := line[j : j+n]; strings.HasSuffix(seq, "m") && strings.HasPrefix(seq, "\x1b[") {
+				if seq == "\x1b[0m" || seq == "\x1b[m" {
+					active = ""
+				} else {
+					active += seq
+				}
+			}
+			j += n
+		}
+		lines[i] = prefix + line
+		if active != "" {
+			lines[i] += "\x1b[0m"
+		}
+	}
+	return lines
+}
@@ -0,0 +1,229 @@
+package tables
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tbl := NewTable("ID", "Description")
+	tbl.AddRow("1", "The quick brown fox jumps over the lazy dog")
+	tbl.AddRow("2", "short")
+	tbl.SetBorder(Left, true, false)
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Right, true, false)
+	tbl.SetBorder(Horizontal, true, false)
+	tbl.SetMaxWidth("Description", 11)
+
+	out, _ := tbl.Render()
+	expected := "│ ID │ Description │\n" +
+		"│ 1  │ The quick   │\n" +
+		"│    │ brown fox   │\n" +
+		"│    │ jumps over  │\n" +
+		"│    │ the lazy    │\n" +
+		"│    │ dog         │\n" +
+		"├────┼─────────────┤\n" +
+		"│ 2  │ short       │\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.SetOverflow("Description", WrapChar)
+	tbl.SetMaxWidth("Description", 8)
+	tbl.SetMaxWidth("ID", 1)
+	tbl.SetBorder(Horizontal, false, false)
+	out, _ = tbl.Render()
+	expected = "│ I │ Descript │\n" +
+		"│ D │ ion      │\n" +
+		"│ 1 │ The quic │\n" +
+		"│   │ k brown  │\n" +
+		"│   │ fox jump │\n" +
+		"│   │ s over t │\n" +
+		"│   │ he lazy  │\n" +
+		"│   │ dog      │\n" +
+		"│ 2 │ short    │\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestWrapClusters(t *testing.T) {
+	tbl := NewTable("A")
+	lines := tbl.wrapChars("日本語テキスト", 5)
+	if len(lines) != 4 || lines[0] != "日本" || lines[3] != "ト" {
+		t.Errorf("Expected wide characters to stay whole, got %q", lines)
+	}
+	lines = tbl.wrapChars("👩‍💻👍🏽ab", 3)
+	if len(lines) != 3 || lines[0] != "👩‍💻" || lines[1] != "👍🏽a" {
+		t.Errorf("Expected emoji sequences to stay whole, got %q", lines)
+	}
+
+	tbl.SetMaxWidth("A", 3)
+	tbl.SetOverflow("A", WrapChar)
+	lines = tbl.cellLines("\x1b[31mabcdef\x1b[0mg", "A")
+	expected := []string{"\x1b[31mabc\x1b[0m", "\x1b[31mdef\x1b[0m", "g"}
+	if len(lines) != 3 || lines[0] != expected[0] || lines[1] != expected[1] || lines[2] != expected[2] {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tbl := NewTable("Path")
+	cases := []struct {
+		overflow Overflow
+		marker   string
+		expected string
+	}{
+		{TruncateEnd, "…", "/usr/loc…"},
+		{TruncateStart, "…", "…l/bin/go"},
+		{TruncateMiddle, "…", "/usr…n/go"},
+		{TruncateEnd, "...", "/usr/l..."},
+		{TruncateMiddle, "", "/usr/n/go"},
+	}
+	for _, c := range cases {
+		tbl.SetTruncationMarker(c.marker)
+		if truncated := tbl.truncate("/usr/local/bin/go", 9, c.overflow); truncated != c.expected {
+			t.Errorf("Overflow %d with %q - Expected %q, got %q", c.overflow, c.marker, c.expected, truncated)
+		}
+	}
+
+	tbl.SetTruncationMarker("…")
+	if truncated := tbl.truncate("日本語テキスト", 6, TruncateEnd); truncated != "日本…" {
+		t.Errorf("Expected wide characters to stay whole, got %q", truncated)
+	}
+	if truncated := tbl.truncate("\x1b[31mcolored\x1b[0m", 4, TruncateEnd); truncated != "\x1b[31mcol\x1b[0m…" {
+		t.Errorf("Expected escape sequences to be kept, got %q", truncated)
+	}
+
+	tbl = NewTable("ID", "Name")
+	tbl.AddRow("0123456789abcdef", "x")
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Header, true, false)
+	tbl.SetMaxWidth("ID", 8)
+	tbl.SetOverflow("ID", TruncateMiddle)
+	out, _ := tbl.Render()
+	expected := "ID       │ Name\n" +
+		"─────────┼─────\n" +
+		"0123…def │ x   \n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	tbl := NewTable("Name", "Description", "Path")
+	tbl.AddRow("alpha", "A fairly long description of the first entry", "/opt/tools/alpha/bin/run")
+	tbl.AddRow("beta", "Short one", "/opt/b")
+	tbl.SetBorder(Left, true, false)
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Right, true, false)
+	tbl.SetOverflow("Path", TruncateStart)
+
+	tbl.SetTargetWidth(50)
+	out, _ := tbl.Render()
+	expected := "│ Name  │ Description        │ Path          │\n" +
+		"│ alpha │ A fairly long      │ …lpha/bin/run │\n" +
+		"│       │ description of the │               │\n" +
+		"│       │ first entry        │               │\n" +
+		"│ beta  │ Short one          │ /opt/b        │\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.SetPriority("Description", 1)
+	tbl.SetPriority("Name", 1)
+	tbl.SetTargetWidth(60)
+	out, _ = tbl.Render()
+	expected = "│ Name  │ Description                            │ Path │\n" +
+		"│ alpha │ A fairly long description of the first │ …run │\n" +
+		"│       │ entry                                  │      │\n" +
+		"│ beta  │ Short one                              │ …t/b │\n"
+	if out != expected {
+		t.Errorf("Expected the low priority Path column to shrink first\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.SetTargetWidth(200)
+	out, _ = tbl.Render()
+	if strings.Count(out, "\n") != 3 {
+		t.Errorf("Expected a table narrower than its target to be left alone, got\n%s", out)
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	if TerminalWidth(os.Stdout) != 0 && isTerminal(os.Stdout) {
+		t.Skip("standard output is a terminal")
+	}
+	t.Setenv("COLUMNS", "72")
+	if width := TerminalWidth(&strings.Builder{}); width != 72 {
+		t.Error("Expected width from COLUMNS, got", width)
+	}
+	t.Setenv("COLUMNS", "")
+	if width := TerminalWidth(&strings.Builder{}); width != 0 {
+		t.Error("Expected no width, got", width)
+	}
+}
+
+func TestMultiLineCells(t *testing.T) {
+	tbl := NewTable("Key", "Value", "Note")
+	tbl.AddRow("a", "first line\nsecond\r\nthird", "mid")
+	tbl.AddRow("b", "one", "x\ny")
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Horizontal, true, false)
+	tbl.VerticalAlign("Key", Bottom)
+	tbl.VerticalAlign("Note", Center)
+
+	out, _ := tbl.Render()
+	expected := "Key │ Value      │ Note\n" +
+		"    │ first line │     \n" +
+		"    │ second     │ mid \n" +
+		"a   │ third      │     \n" +
+		"────┼────────────┼─────\n" +
+		"    │ one        │ x   \n" +
+		"b   │            │ y   \n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.SetMaxWidth("Value", 6)
+	tbl.SetOverflow("Value", TruncateEnd)
+	out, _ = tbl.Render()
+	if !strings.Contains(out, "│ first… │") || !strings.Contains(out, "│ third  │") {
+		t.Errorf("Expected each line to be truncated on its own, got\n%s", out)
+	}
+}
+
+func TestAlignment(t *testing.T) {
+	tbl := NewTable("Label", "Amount")
+	tbl.AddRow("pi", "3.14")
+	tbl.AddRow("big", "120.5")
+	tbl.AddRow("whole", "7")
+	tbl.AddRow("none", "n/a")
+	tbl.SetBorder(Center, true, false)
+	tbl.Align("Label", Center, true)
+	tbl.Align("Amount", Decimal, false)
+
+	out, _ := tbl.Render()
+	expected := "Label │ Amount\n" +
+		" pi   │   3.14\n" +
+		" big  │ 120.5 \n" +
+		"whole │   7   \n" +
+		"none  │    n/a\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.SetCenterBias(Right)
+	out, _ = tbl.Render()
+	if !strings.HasPrefix(out, "Label │ Amount\n  pi  │") || !strings.Contains(out, "\n big  │") {
+		t.Errorf("Expected centered text to lean right, got\n%s", out)
+	}
+
+	md, _ := tbl.Markdown()
+	if !strings.Contains(md, "| :---: | -----: |") {
+		t.Errorf("Expected center and decimal alignment in Markdown, got\n%s", md)
+	}
+	if !strings.Contains(md, "\n|   pi  |   3.14 |\n") {
+		t.Errorf("Expected centered Markdown cells to lean right, got\n%s", md)
+	}
+}
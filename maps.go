@@ -0,0 +1,53 @@
+package tables
+
+import (
+	"reflect"
+	"sort"
+)
+
+// MapOptions configures FromMaps.
+type MapOptions struct {
+	// SortColumns orders the discovered columns alphabetically instead of by
+	// first appearance.
+	SortColumns bool
+	// Placeholder fills the cells of columns a record has no key for.
+	Placeholder string
+}
+
+// FromMaps builds a table from records with varying keys, such as decoded JSON
+// objects. The columns are the union of all keys, in order of first appearance
+// across records; keys first seen in the same record are ordered alphabetically
+// since maps have no order of their own. Values are formatted as FromStructs
+// does, with nil rendering as an empty cell.
+func FromMaps(records []map[string]interface{}, opts MapOptions) *Table {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, record := range records {
+		var keys []string
+		for key := range record {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		columns = append(columns, keys...)
+	}
+	if opts.SortColumns {
+		sort.Strings(columns)
+	}
+
+	tbl := NewTable(columns...)
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, colName := range columns {
+			if val, ok := record[colName]; ok {
+				row[i] = formatValue(reflect.ValueOf(val), "")
+			} else {
+				row[i] = opts.Placeholder
+			}
+		}
+		tbl.AddRow(row...)
+	}
+	return tbl
+}
@@ -0,0 +1,70 @@
+package tables
+
+import (
+	"io"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	"|", "\\|",
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+// WriteMarkdown renders the table to w as a GitHub-flavored Markdown pipe table.
+// Column alignment is carried over to the delimiter row, and pipes and newlines
+// inside cells are escaped so they don't break the table.
+func (tbl *Table) WriteMarkdown(w io.Writer) error {
+	tbl = tbl.view()
+	tw := &tableWriter{w: w}
+	header := make([]string, len(tbl.columns))
+	rows := make([][]string, len(tbl.rows))
+	widths := make([]int, len(tbl.columns))
+	for i, colName := range tbl.columns {
+		header[i] = markdownEscaper.Replace(colName)
+		widths[i] = max(3, tbl.stringWidth(header[i]))
+	}
+	for r, row := range tbl.rows {
+		rows[r] = make([]string, len(row))
+		for i, cell := range row {
+			rows[r][i] = markdownEscaper.Replace(cell)
+			widths[i] = max(widths[i], tbl.stringWidth(rows[r][i]))
+		}
+	}
+
+	tbl.printMarkdownRow(tw, header, widths)
+	for i, colName := range tbl.columns {
+		switch tbl.columnAlignment[colName] {
+		case Center:
+			tw.print("| :", strings.Repeat("-", widths[i]-2), ": ")
+		case Right, Decimal:
+			tw.print("| ", strings.Repeat("-", widths[i]-1), ": ")
+		default:
+			tw.print("| :", strings.Repeat("-", widths[i]-1), " ")
+		}
+	}
+	tw.print("|\n")
+	for _, row := range rows {
+		tbl.printMarkdownRow(tw, row, widths)
+	}
+	return tw.err
+}
+
+// Markdown returns the table as a GitHub-flavored Markdown pipe table.
+func (tbl *Table) Markdown() (string, error) {
+	var sb strings.Builder
+	err := tbl.WriteMarkdown(&sb)
+	return sb.String(), err
+}
+
+func (tbl *Table) printMarkdownRow(tw *tableWriter, cells []string, widths []int) {
+	for i, cell := range cells {
+		align := tbl.columnAlignment[tbl.columns[i]]
+		if align != Center && align != Right && align != Decimal {
+			align = Left
+		}
+		tw.print("| ", tbl.alignCell(cell, widths[i], align), " ")
+	}
+	tw.print("|\n")
+}
@@ -0,0 +1,94 @@
+package tables
+
+import "strconv"
+
+// Transpose returns a new table with rows and columns swapped. The first
+// column's cells become the column names, after the first column's own name,
+// and every other column becomes a row led by its name. Repeated names are
+// told apart with a " (2)", " (3)", ... suffix.
+func (tbl *Table) Transpose() *Table {
+	if len(tbl.columns) == 0 {
+		return NewTable()
+	}
+	colNames := append([]string{tbl.columns[0]}, columnValues(tbl.rows, 0)...)
+	transposed := NewTable(uniqueNames(colNames)...)
+	for col := 1; col < len(tbl.columns); col++ {
+		transposed.AddRow(append([]string{tbl.columns[col]}, columnValues(tbl.rows, col)...)...)
+	}
+	return transposed
+}
+
+// Pivot turns long data into wide form. The result has a row for each distinct
+// value of rowKey and a column for each distinct value of colKey, both in the
+// order they first appear, and each cell aggregates the valueCol cells of the
+// rows sharing that pair of keys, for example with Sum. Cells with no rows are
+// left empty. A nil aggregate keeps the last value. The value column's
+// alignment carries over to the new columns.
+func (tbl *Table) Pivot(rowKey, colKey, valueCol string, aggregate AggregateFunc) (*Table, error) {
+	if err := tbl.checkColumns([]string{rowKey, colKey, valueCol}); err != nil {
+		return nil, err
+	}
+	if aggregate == nil {
+		aggregate = func(values []string) string {
+			return values[len(values)-1]
+		}
+	}
+	rowCol := getSliceIndexString(rowKey, tbl.columns)
+	colCol := getSliceIndexString(colKey, tbl.columns)
+	valCol := getSliceIndexString(valueCol, tbl.columns)
+
+	var rowKeys, colKeys []string
+	rowIndex := make(map[string]int)
+	colIndex := make(map[string]int)
+	values := make(map[[2]int][]string)
+	for _, row := range tbl.rows {
+		r, ok := rowIndex[row[rowCol]]
+		if !ok {
+			r = len(rowKeys)
+			rowIndex[row[rowCol]] = r
+			rowKeys = append(rowKeys, row[rowCol])
+		}
+		c, ok := colIndex[row[colCol]]
+		if !ok {
+			c = len(colKeys)
+			colIndex[row[colCol]] = c
+			colKeys = append(colKeys, row[colCol])
+		}
+		values[[2]int{r, c}] = append(values[[2]int{r, c}], row[valCol])
+	}
+
+	colNames := uniqueNames(append([]string{rowKey}, colKeys...))
+	pivoted := NewTable(colNames...)
+	pivoted.Align(rowKey, tbl.columnAlignment[rowKey], true)
+	for _, colName := range colNames[1:] {
+		pivoted.Align(colName, tbl.columnAlignment[valueCol], false)
+		pivoted.headerAlignment[colName] = tbl.headerAlignment[valueCol]
+	}
+	for r, key := range rowKeys {
+		cells := make([]string, len(colNames))
+		cells[0] = key
+		for c := range colKeys {
+			if vals, ok := values[[2]int{r, c}]; ok {
+				cells[c+1] = aggregate(vals)
+			}
+		}
+		pivoted.AddRow(cells...)
+	}
+	return pivoted, nil
+}
+
+// uniqueNames suffixes repeated names with " (2)", " (3)", ... so each can name
+// its own column.
+func uniqueNames(names []string) []string {
+	unique := make([]string, len(names))
+	seen := make(map[string]bool)
+	for i, name := range names {
+		candidate := name
+		for n := 2; seen[candidate]; n++ {
+			candidate = name + " (" + strconv.Itoa(n) + ")"
+		}
+		seen[candidate] = true
+		unique[i] = candidate
+	}
+	return unique
+}
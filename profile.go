@@ -0,0 +1,146 @@
+package tables
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// ColorProfile is the range of colors an output can display.
+type ColorProfile int
+
+const (
+	AutoColor ColorProfile = iota // detect the profile from the output with DetectColorProfile
+	NoColor                       // no styling at all
+	ANSI16                        // the 16 standard colors
+	ANSI256                       // the 256 color palette
+	TrueColor                     // 24-bit colors
+)
+
+// ansi16Palette holds the usual RGB values of the 16 standard colors, used to
+// find the closest match when degrading colors.
+var ansi16Palette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel intensities of the 6x6x6 color cube in the 256
+// color palette.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// DetectColorProfile works out how much styling w can display. NO_COLOR, or
+// CLICOLOR=0, turns styling off. Otherwise outputs that aren't terminals get no
+// color unless CLICOLOR_FORCE is set to something other than 0, and terminals
+// are rated from COLORTERM and TERM, with TERM=dumb getting no color.
+func DetectColorProfile(w io.Writer) ColorProfile {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("CLICOLOR") == "0" {
+		return NoColor
+	}
+	forced := os.Getenv("CLICOLOR_FORCE") != "" && os.Getenv("CLICOLOR_FORCE") != "0"
+	term := strings.ToLower(os.Getenv("TERM"))
+	if !forced && (!isTerminal(w) || term == "dumb") {
+		return NoColor
+	}
+
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return TrueColor
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "direct"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return ANSI256
+	}
+	return ANSI16
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isTTY(f)
+}
+
+// SetColorProfile overrides the color profile detected from the output when
+// rendering. AutoColor restores detection.
+func (tbl *Table) SetColorProfile(profile ColorProfile) {
+	tbl.colorProfile = profile
+}
+
+// degrade converts the color to the closest one the profile can display.
+func (c Color) degrade(profile ColorProfile) Color {
+	switch {
+	case profile == NoColor:
+		return Color{}
+	case profile == ANSI256 && c.kind == colorRGB:
+		return Color256(rgbTo256(c.rgb()))
+	case profile == ANSI16 && (c.kind == colorRGB || c.kind == color256):
+		return Color16(rgbTo16(c.rgb()))
+	}
+	return c
+}
+
+// rgb returns the red, green and blue components of a 256 color or truecolor color.
+func (c Color) rgb() (r, g, b int) {
+	if c.kind == colorRGB {
+		return int(c.value >> 16), int(c.value >> 8 & 0xFF), int(c.value & 0xFF)
+	}
+	n := int(c.value)
+	switch {
+	case n < 16:
+		return ansi16Palette[n][0], ansi16Palette[n][1], ansi16Palette[n][2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	}
+	gray := 8 + (n-232)*10
+	return gray, gray, gray
+}
+
+// rgbTo256 finds the closest color in the cube or grayscale ramp of the 256 color
+// palette.
+func rgbTo256(r, g, b int) uint8 {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 248:
+			return 231
+		}
+		return uint8(232 + (r-8)*24/247)
+	}
+	level := func(v int) int {
+		switch {
+		case v < 48:
+			return 0
+		case v < 115:
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return uint8(16 + 36*level(r) + 6*level(g) + level(b))
+}
+
+// rgbTo16 finds the closest of the 16 standard colors.
+func rgbTo16(r, g, b int) uint8 {
+	best, bestDistance := 0, -1
+	for i, p := range ansi16Palette {
+		dr, dg, db := r-p[0], g-p[1], b-p[2]
+		if distance := dr*dr + dg*dg + db*db; bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	return uint8(best)
+}
+
+// degrade converts the style to what the profile can display. NoColor drops the
+// style entirely.
+func (s Style) degrade(profile ColorProfile) Style {
+	if profile == NoColor {
+		return Style{}
+	}
+	s.Foreground = s.Foreground.degrade(profile)
+	s.Background = s.Background.degrade(profile)
+	return s
+}
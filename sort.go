@@ -0,0 +1,228 @@
+package tables
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Comparator orders two cells, returning a negative number if a sorts before b,
+// a positive number if it sorts after and zero if they are equal.
+type Comparator func(a, b string) int
+
+// SortKey is a column to sort rows by.
+type SortKey struct {
+	Column     string
+	Descending bool
+	// Compare orders the column's cells. It defaults to CompareString.
+	Compare Comparator
+}
+
+// DateLayouts are the layouts CompareDate tries, in order, when parsing cells.
+var DateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	"01/02/2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+var byteSize = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([kKmMgGtTpPeE]?)(i?)[bB]?$`)
+
+// SortBy sorts the rows in place by the given keys, the first key taking
+// precedence. The sort is stable, so rows that compare equal on every key keep
+// their order. Row and cell styles move with their rows.
+func (tbl *Table) SortBy(keys ...SortKey) error {
+	order, err := tbl.sortOrder(keys)
+	if err != nil {
+		return err
+	}
+	tbl.permuteRows(order)
+	return nil
+}
+
+// Sorted returns a sorted copy of the table, leaving the table itself untouched.
+func (tbl *Table) Sorted(keys ...SortKey) (*Table, error) {
+	sorted := tbl.clone()
+	return sorted, sorted.SortBy(keys...)
+}
+
+// sortOrder returns the row indexes in sorted order.
+func (tbl *Table) sortOrder(keys []SortKey) ([]int, error) {
+	cols := make([]int, len(keys))
+	for i, key := range keys {
+		if cols[i] = getSliceIndexString(key.Column, tbl.columns); cols[i] == -1 {
+			return nil, fmt.Errorf("Unknown column %q", key.Column)
+		}
+	}
+	order := make([]int, len(tbl.rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := tbl.rows[order[i]], tbl.rows[order[j]]
+		for k, key := range keys {
+			compare := key.Compare
+			if compare == nil {
+				compare = CompareString
+			}
+			c := compare(StripANSI(a[cols[k]]), StripANSI(b[cols[k]]))
+			if c != 0 {
+				return (c < 0) != key.Descending
+			}
+		}
+		return false
+	})
+	return order, nil
+}
+
+// permuteRows rearranges the rows so that row i is the former row order[i],
+// carrying row and cell styles along. Rows left out of order are dropped.
+func (tbl *Table) permuteRows(order []int) {
+	rows := make([][]string, len(order))
+	rowStyles := make(map[int]Style)
+	cellStyles := make(map[cellRef]Style)
+	newIndex := make(map[int]int)
+	for i, old := range order {
+		rows[i] = tbl.rows[old]
+		newIndex[old] = i
+		if style, ok := tbl.rowStyles[old]; ok {
+			rowStyles[i] = style
+		}
+	}
+	for ref, style := range tbl.cellStyles {
+		if i, ok := newIndex[ref.row]; ok {
+			cellStyles[cellRef{i, ref.column}] = style
+		}
+	}
+	tbl.rows, tbl.rowStyles, tbl.cellStyles = rows, rowStyles, cellStyles
+}
+
+// CompareString orders cells lexically.
+func CompareString(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// CompareNatural orders cells lexically, except that runs of digits are
+// compared by their numeric value, so "file2" sorts before "file10".
+func CompareNatural(a, b string) int {
+	for a != "" && b != "" {
+		ca, restA := naturalChunk(a)
+		cb, restB := naturalChunk(b)
+		if isDigit(ca[0]) && isDigit(cb[0]) {
+			na, nb := strings.TrimLeft(ca, "0"), strings.TrimLeft(cb, "0")
+			if len(na) != len(nb) {
+				return len(na) - len(nb)
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(ca, cb); c != 0 {
+			return c
+		}
+		a, b = restA, restB
+	}
+	return len(a) - len(b)
+}
+
+// naturalChunk splits off the leading run of digits or non-digits.
+func naturalChunk(s string) (chunk, rest string) {
+	digits := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// CompareNumeric orders cells by their numeric value. Cells that aren't numbers
+// sort after those that are.
+func CompareNumeric(a, b string) int {
+	return compareParsed(a, b, func(s string) (float64, bool) {
+		value, _, ok := parseNumber(s)
+		return value, ok
+	})
+}
+
+// CompareBytes orders human readable byte sizes such as 512, 1.5K, 20 MB and
+// 3GiB. Units with an i are powers of 1024, the others powers of 1000. Cells
+// that aren't sizes sort after those that are.
+func CompareBytes(a, b string) int {
+	return compareParsed(a, b, parseBytes)
+}
+
+func parseBytes(s string) (float64, bool) {
+	m := byteSize.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	base := ternary(m[3] != "", 1024.0, 1000.0).(float64)
+	for i := strings.Index("KMGTPE", strings.ToUpper(m[2])); m[2] != "" && i >= 0; i-- {
+		value *= base
+	}
+	return value, true
+}
+
+// CompareDuration orders durations such as 1h30m or 250ms, as accepted by
+// time.ParseDuration. Cells that aren't durations sort after those that are.
+func CompareDuration(a, b string) int {
+	return compareParsed(a, b, func(s string) (float64, bool) {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		return float64(d), err == nil
+	})
+}
+
+// CompareDate orders dates and times in any of the DateLayouts. Cells that
+// aren't dates sort after those that are.
+func CompareDate(a, b string) int {
+	return compareParsed(a, b, func(s string) (float64, bool) {
+		s = strings.TrimSpace(s)
+		for _, layout := range DateLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return float64(t.UnixNano()), true
+			}
+		}
+		return 0, false
+	})
+}
+
+// compareParsed compares cells by a parsed value, putting cells that can't be
+// parsed last, in lexical order.
+func compareParsed(a, b string, parse func(string) (float64, bool)) int {
+	va, okA := parse(a)
+	vb, okB := parse(b)
+	switch {
+	case okA && okB:
+		if va < vb {
+			return -1
+		} else if va > vb {
+			return 1
+		}
+		return 0
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
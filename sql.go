@@ -0,0 +1,76 @@
+package tables
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SQLOptions configures FromSQLRows.
+type SQLOptions struct {
+	// MaxRows caps the number of rows read. Zero reads every row.
+	MaxRows int
+	// Null is the text shown for NULL values. It defaults to "NULL".
+	Null string
+	// NullAsEmpty shows NULL values as empty cells, overriding Null.
+	NullAsEmpty bool
+	// TimeFormat is the layout for time.Time values. It defaults to RFC 3339.
+	TimeFormat string
+}
+
+// FromSQLRows builds a table from a query result, naming the columns after
+// rows.Columns(). Rows are read until they run out or opts.MaxRows is reached;
+// closing rows remains the caller's responsibility.
+func FromSQLRows(rows *sql.Rows, opts SQLOptions) (*Table, error) {
+	if opts.NullAsEmpty {
+		opts.Null = ""
+	} else if opts.Null == "" {
+		opts.Null = "NULL"
+	}
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = time.RFC3339
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	tbl := NewTable(columns...)
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for (opts.MaxRows == 0 || len(tbl.rows) < opts.MaxRows) && rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(columns))
+		for i, val := range values {
+			row[i] = formatSQLValue(val, opts)
+		}
+		tbl.AddRow(row...)
+	}
+	return tbl, rows.Err()
+}
+
+func formatSQLValue(val interface{}, opts SQLOptions) string {
+	switch v := val.(type) {
+	case nil:
+		return opts.Null
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(opts.TimeFormat)
+	}
+	return fmt.Sprint(val)
+}
@@ -0,0 +1,102 @@
+package tables
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeDriver serves a fixed result set for every query.
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct{}
+
+type fakeRows struct {
+	next int
+}
+
+var fakeColumns = []string{"id", "name", "price", "paid", "created", "blob"}
+
+var fakeData = [][]driver.Value{
+	{int64(1), "apple", 0.5, true, time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), []byte("raw")},
+	{int64(2), nil, 12.25, false, nil, nil},
+	{int64(3), "cherry", float64(3), true, nil, nil},
+}
+
+func init() {
+	sql.Register("tabula-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error)   { return fakeConn{}, nil }
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+func (fakeStmt) Close() error                              { return nil }
+func (fakeStmt) NumInput() int                             { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) { return &fakeRows{}, nil }
+func (*fakeRows) Columns() []string                             { return fakeColumns }
+func (*fakeRows) Close() error                                  { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(fakeData) {
+		return io.EOF
+	}
+	copy(dest, fakeData[r.next])
+	r.next++
+	return nil
+}
+
+func TestFromSQLRows(t *testing.T) {
+	db, err := sql.Open("tabula-fake", "")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT *")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	tbl, err := FromSQLRows(rows, SQLOptions{Null: "-"})
+	rows.Close()
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := [][]string{
+		{"1", "apple", "0.5", "true", "2021-01-02T03:04:05Z", "raw"},
+		{"2", "-", "12.25", "false", "-", "-"},
+		{"3", "cherry", "3", "true", "-", "-"},
+	}
+	if len(tbl.columns) != len(fakeColumns) || len(tbl.rows) != len(expected) {
+		t.Fatalf("Unexpected table shape %v %q", tbl.columns, tbl.rows)
+	}
+	for r, row := range expected {
+		for c, cell := range row {
+			if tbl.rows[r][c] != cell {
+				t.Errorf("Row %d column %d - Expected %q, got %q", r, c, cell, tbl.rows[r][c])
+			}
+		}
+	}
+
+	rows, _ = db.Query("SELECT *")
+	defer rows.Close()
+	tbl, err = FromSQLRows(rows, SQLOptions{MaxRows: 2})
+	if err != nil || len(tbl.rows) != 2 || tbl.rows[1][1] != "NULL" {
+		t.Errorf("Expected 2 rows with NULL placeholder, got %q (%v)", tbl.rows, err)
+	}
+
+	rows, _ = db.Query("SELECT *")
+	defer rows.Close()
+	tbl, err = FromSQLRows(rows, SQLOptions{Null: "-", NullAsEmpty: true})
+	if err != nil || tbl.rows[1][1] != "" || tbl.rows[2][4] != "" {
+		t.Errorf("Expected NULL as empty cells, got %q (%v)", tbl.rows, err)
+	}
+}
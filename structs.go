@@ -0,0 +1,254 @@
+package tables
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// structField describes how a struct field maps onto a table column.
+type structField struct {
+	index  []int
+	name   string
+	tagged bool
+	align  int
+	format string
+}
+
+// FromStructs builds a table from a slice of structs or struct pointers. Every
+// exported field becomes a column named after the field, and fields of embedded
+// structs are promoted into the parent, where shallower fields shadow deeper ones
+// of the same name as in Go. A `tabula` struct tag customizes the column:
+//
+//	Price float64 `tabula:"Unit Price,align=right,format=%.2f"`
+//	Notes string  `tabula:",omit"`
+//
+// The first tag value renames the column, align takes left, center or right,
+// format is a fmt verb (or a layout for time.Time fields, which default to
+// RFC 3339), and omit or a tag of "-" leaves the field out. Nil pointers render
+// as empty cells and fmt.Stringer values use their String method.
+func FromStructs(slice interface{}) (tbl *Table, err error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("FromStructs expects a slice of structs, got %T", slice)
+	}
+	elemType := v.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("FromStructs expects a slice of structs, got %T", slice)
+	}
+
+	fields, err := structFields(elemType, nil, nil)
+	if err != nil {
+		return
+	}
+	if fields, err = dominantFields(fields); err != nil {
+		return
+	}
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.name
+	}
+	tbl = NewTable(columns...)
+	for _, field := range fields {
+		if field.align != 0 {
+			tbl.Align(field.name, field.align, true)
+		}
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			if fv, ok := fieldByIndex(elem, field.index); ok {
+				row[j] = formatValue(fv, field.format)
+			}
+		}
+		if err = tbl.AddRow(row...); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// structFields lists the columns of a struct type, flattening embedded structs.
+// path holds the struct types being flattened, so a type embedding itself,
+// directly or further down, is skipped instead of recursing forever.
+func structFields(t reflect.Type, index []int, path []reflect.Type) (fields []structField, err error) {
+	path = append(path, t)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := structField{
+			index: append(append([]int{}, index...), i),
+			name:  sf.Name,
+		}
+		tag := sf.Tag.Get("tabula")
+		if tag == "-" {
+			continue
+		}
+		name, omit := "", false
+		for n, opt := range strings.Split(tag, ",") {
+			switch {
+			case n == 0:
+				name = opt
+			case opt == "omit":
+				omit = true
+			case strings.HasPrefix(opt, "align="):
+				switch strings.ToLower(strings.TrimPrefix(opt, "align=")) {
+				case "left":
+					field.align = Left
+				case "center":
+					field.align = Center
+				case "right":
+					field.align = Right
+				default:
+					return nil, fmt.Errorf("Field %s has invalid tabula tag option %q", sf.Name, opt)
+				}
+			case strings.HasPrefix(opt, "format="):
+				field.format = strings.TrimPrefix(opt, "format=")
+			case opt != "":
+				return nil, fmt.Errorf("Field %s has invalid tabula tag option %q", sf.Name, opt)
+			}
+		}
+		if omit {
+			continue
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && ft.Kind() == reflect.Struct && name == "" && !isScalarStruct(ft) {
+			if typeOnPath(ft, path) {
+				continue
+			}
+			embedded, err := structFields(ft, field.index, path)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		if name != "" {
+			field.name = name
+			field.tagged = true
+		}
+		fields = append(fields, field)
+	}
+	return
+}
+
+// dominantFields applies Go's rules for promoted fields to columns sharing a
+// name: the shallowest field wins, then the one named by a tabula tag. Columns
+// that still clash are an error.
+func dominantFields(fields []structField) ([]structField, error) {
+	byName := make(map[string][]structField)
+	for _, field := range fields {
+		byName[field.name] = append(byName[field.name], field)
+	}
+	var dominant []structField
+	for _, field := range fields {
+		rivals := byName[field.name]
+		if len(rivals) == 1 {
+			dominant = append(dominant, field)
+			continue
+		}
+		depth, tagged := len(field.index), 0
+		for _, rival := range rivals {
+			depth = min(depth, len(rival.index))
+		}
+		var best []structField
+		for _, rival := range rivals {
+			if len(rival.index) == depth {
+				best = append(best, rival)
+				if rival.tagged {
+					tagged++
+				}
+			}
+		}
+		switch {
+		case len(best) == 1 && len(best[0].index) == len(field.index):
+			dominant = append(dominant, field)
+		case len(best) > 1 && tagged == 1 && len(field.index) == depth && field.tagged:
+			dominant = append(dominant, field)
+		case len(best) > 1 && tagged != 1:
+			return nil, fmt.Errorf("Fields map to the same column %q", field.name)
+		}
+	}
+	return dominant, nil
+}
+
+func typeOnPath(t reflect.Type, path []reflect.Type) bool {
+	for _, p := range path {
+		if p == t {
+			return true
+		}
+	}
+	return false
+}
+
+// isScalarStruct reports whether a struct type renders as a single value rather
+// than having its fields promoted when embedded.
+func isScalarStruct(t reflect.Type) bool {
+	return t == timeType || t.Implements(stringerType) || reflect.PtrTo(t).Implements(stringerType)
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead of
+// panicking when it meets a nil pointer along the way.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// formatValue renders a value as cell text. Nil pointers and interfaces render as
+// an empty string, time.Time uses format as its layout, and other values use
+// format as a fmt verb when it is set.
+func formatValue(v reflect.Value, format string) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		if v.Kind() == reflect.Ptr && v.Type().Elem() != timeType && v.Type().Implements(stringerType) && format == "" {
+			return v.Interface().(fmt.Stringer).String()
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	val := v.Interface()
+	if t, ok := val.(time.Time); ok {
+		return t.Format(ternary(format == "", time.RFC3339, format).(string))
+	}
+	if format != "" {
+		return fmt.Sprintf(format, val)
+	}
+	if s, ok := val.(fmt.Stringer); ok {
+		return s.String()
+	}
+	if v.CanAddr() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+	return fmt.Sprint(val)
+}
@@ -0,0 +1,170 @@
+package tables
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Color is a terminal color in the 16 color, 256 color or truecolor palette. The
+// zero Color leaves the terminal's default color in place.
+type Color struct {
+	kind  int
+	value uint32
+}
+
+const (
+	colorDefault = iota
+	color16
+	color256
+	colorRGB
+)
+
+// The 16 standard terminal colors.
+var (
+	Black         = Color16(0)
+	Red           = Color16(1)
+	Green         = Color16(2)
+	Yellow        = Color16(3)
+	Blue          = Color16(4)
+	Magenta       = Color16(5)
+	Cyan          = Color16(6)
+	White         = Color16(7)
+	BrightBlack   = Color16(8)
+	BrightRed     = Color16(9)
+	BrightGreen   = Color16(10)
+	BrightYellow  = Color16(11)
+	BrightBlue    = Color16(12)
+	BrightMagenta = Color16(13)
+	BrightCyan    = Color16(14)
+	BrightWhite   = Color16(15)
+)
+
+// Color16 returns one of the 16 standard terminal colors, numbered 0 to 15.
+func Color16(n uint8) Color {
+	return Color{kind: color16, value: uint32(n % 16)}
+}
+
+// Color256 returns a color from the 256 color palette.
+func Color256(n uint8) Color {
+	return Color{kind: color256, value: uint32(n)}
+}
+
+// RGB returns a 24-bit truecolor color.
+func RGB(r, g, b uint8) Color {
+	return Color{kind: colorRGB, value: uint32(r)<<16 | uint32(g)<<8 | uint32(b)}
+}
+
+// sgr returns the SGR parameters selecting the color, for the foreground or the
+// background.
+func (c Color) sgr(background bool) string {
+	switch c.kind {
+	case color16:
+		base := ternary(background, 40, 30).(int)
+		if c.value >= 8 {
+			base += 60 - 8
+		}
+		return strconv.Itoa(base + int(c.value))
+	case color256:
+		return ternary(background, "48;5;", "38;5;").(string) + strconv.Itoa(int(c.value))
+	case colorRGB:
+		return ternary(background, "48;2;", "38;2;").(string) +
+			strconv.Itoa(int(c.value>>16)) + ";" + strconv.Itoa(int(c.value>>8&0xFF)) + ";" + strconv.Itoa(int(c.value&0xFF))
+	}
+	return ""
+}
+
+// Style describes the colors and text attributes of a cell.
+type Style struct {
+	Foreground Color
+	Background Color
+	Bold       bool
+	Dim        bool
+	Italic     bool
+	Underline  bool
+}
+
+// merge layers o on top of s: colors set in o replace those in s, and attributes
+// set in either are kept.
+func (s Style) merge(o Style) Style {
+	if o.Foreground.kind != colorDefault {
+		s.Foreground = o.Foreground
+	}
+	if o.Background.kind != colorDefault {
+		s.Background = o.Background
+	}
+	s.Bold = s.Bold || o.Bold
+	s.Dim = s.Dim || o.Dim
+	s.Italic = s.Italic || o.Italic
+	s.Underline = s.Underline || o.Underline
+	return s
+}
+
+// sequence returns the SGR escape sequence that switches the style on, or an
+// empty string for the zero Style.
+func (s Style) sequence() string {
+	var params []string
+	if s.Bold {
+		params = append(params, "1")
+	}
+	if s.Dim {
+		params = append(params, "2")
+	}
+	if s.Italic {
+		params = append(params, "3")
+	}
+	if s.Underline {
+		params = append(params, "4")
+	}
+	if fg := s.Foreground.sgr(false); fg != "" {
+		params = append(params, fg)
+	}
+	if bg := s.Background.sgr(true); bg != "" {
+		params = append(params, bg)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// apply wraps text in the style's escape sequences, degraded to the profile.
+func (s Style) apply(text string, profile ColorProfile) string {
+	seq := s.degrade(profile).sequence()
+	if seq == "" || text == "" {
+		return text
+	}
+	return seq + text + "\x1b[0m"
+}
+
+// cellRef identifies a single cell for per-cell styling.
+type cellRef struct {
+	row    int
+	column string
+}
+
+// SetHeaderStyle sets the style of the column names.
+func (tbl *Table) SetHeaderStyle(style Style) {
+	tbl.headerStyle = style
+}
+
+// SetColumnStyle sets the style of every cell in the column, header excluded.
+func (tbl *Table) SetColumnStyle(colName string, style Style) {
+	tbl.columnStyles[colName] = style
+}
+
+// SetRowStyle sets the style of every cell in the row, numbered from 0 in the
+// order rows were added. It is layered on top of column styles.
+func (tbl *Table) SetRowStyle(rowNum int, style Style) {
+	tbl.rowStyles[rowNum] = style
+}
+
+// SetCellStyle sets the style of a single cell, layered on top of its row and
+// column styles.
+func (tbl *Table) SetCellStyle(rowNum int, colName string, style Style) {
+	tbl.cellStyles[cellRef{rowNum, colName}] = style
+}
+
+// cellStyle returns the combined column, row and cell style of a cell.
+func (tbl *Table) cellStyle(rowNum int, colName string) Style {
+	return tbl.columnStyles[colName].merge(tbl.rowStyles[rowNum]).merge(tbl.cellStyles[cellRef{rowNum, colName}])
+}
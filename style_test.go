@@ -0,0 +1,130 @@
+package tables
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestStyleSequence(t *testing.T) {
+	cases := []struct {
+		style    Style
+		expected string
+	}{
+		{Style{}, ""},
+		{Style{Bold: true}, "\x1b[1m"},
+		{Style{Foreground: Red, Background: BrightBlue}, "\x1b[31;104m"},
+		{Style{Foreground: Color256(208), Underline: true}, "\x1b[4;38;5;208m"},
+		{Style{Background: RGB(10, 20, 30), Italic: true, Dim: true}, "\x1b[2;3;48;2;10;20;30m"},
+	}
+	for _, c := range cases {
+		if seq := c.style.sequence(); seq != c.expected {
+			t.Errorf("Expected %q, got %q", c.expected, seq)
+		}
+	}
+}
+
+func TestStyledTable(t *testing.T) {
+	tbl := NewTable("Job", "Status")
+	tbl.AddRow("build", "ok")
+	tbl.AddRow("deploy", "failed")
+	tbl.SetBorder(Center, true, false)
+	tbl.SetHeaderStyle(Style{Bold: true})
+	tbl.SetColumnStyle("Status", Style{Foreground: Green})
+	tbl.SetRowStyle(1, Style{Underline: true})
+	tbl.SetCellStyle(1, "Status", Style{Foreground: Red})
+	tbl.SetColorProfile(TrueColor)
+
+	out, _ := tbl.Render()
+	expected := "\x1b[1mJob\x1b[0m    │ \x1b[1mStatus\x1b[0m\n" +
+		"build  │ \x1b[32mok\x1b[0m    \n" +
+		"\x1b[4mdeploy\x1b[0m │ \x1b[4;31mfailed\x1b[0m\n"
+	if out != expected {
+		t.Errorf("Expected %q, got %q", expected, out)
+	}
+}
+
+func TestColorProfile(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("COLORTERM", "")
+
+	var sb strings.Builder
+	if profile := DetectColorProfile(&sb); profile != NoColor {
+		t.Error("Expected NoColor for a non-terminal writer, got", profile)
+	}
+	t.Setenv("CLICOLOR_FORCE", "1")
+	if profile := DetectColorProfile(&sb); profile != ANSI256 {
+		t.Error("Expected ANSI256 when forced with a 256 color TERM, got", profile)
+	}
+	t.Setenv("COLORTERM", "truecolor")
+	if profile := DetectColorProfile(&sb); profile != TrueColor {
+		t.Error("Expected TrueColor with COLORTERM=truecolor, got", profile)
+	}
+	t.Setenv("NO_COLOR", "1")
+	if profile := DetectColorProfile(&sb); profile != NoColor {
+		t.Error("Expected NO_COLOR to win, got", profile)
+	}
+
+	tbl := NewTable("A")
+	tbl.AddRow("x")
+	tbl.SetColumnStyle("A", Style{Foreground: RGB(255, 135, 0), Bold: true})
+	expected := map[ColorProfile]string{
+		NoColor:   "A\nx\n",
+		ANSI16:    "A\n\x1b[1;33mx\x1b[0m\n",
+		ANSI256:   "A\n\x1b[1;38;5;208mx\x1b[0m\n",
+		TrueColor: "A\n\x1b[1;38;2;255;135;0mx\x1b[0m\n",
+	}
+	for profile, out := range expected {
+		tbl.SetColorProfile(profile)
+		if rendered, _ := tbl.Render(); rendered != out {
+			t.Errorf("Profile %d - Expected %q, got %q", profile, out, rendered)
+		}
+	}
+
+	tbl.SetColorProfile(AutoColor)
+	if rendered, _ := tbl.Render(); rendered != expected[NoColor] {
+		t.Errorf("Expected styling stripped with NO_COLOR, got %q", rendered)
+	}
+}
+
+func TestRenderColorProfile(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	t.Setenv("TERM", "xterm")
+	t.Setenv("COLORTERM", "")
+
+	if runtime.GOOS != "linux" {
+		t.Skip("Terminal detection needs Linux")
+	}
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer null.Close()
+	if isTerminal(null) {
+		t.Error("Expected the null device not to count as a terminal")
+	}
+	// The master side of a pseudo-terminal is a real terminal.
+	terminal, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skip("No pseudo-terminals:", err)
+	}
+	defer terminal.Close()
+
+	tbl := NewTable("A")
+	tbl.AddRow("x")
+	tbl.SetColumnStyle("A", Style{Foreground: Red})
+	tbl.SetWriter(terminal)
+	if rendered, _ := tbl.Render(); rendered != "A\n\x1b[31mx\x1b[0m\n" {
+		t.Errorf("Expected Render to style for the terminal Print writes to, got %q", rendered)
+	}
+	tbl.SetWriter(&strings.Builder{})
+	if rendered := tbl.String(); rendered != "A\nx\n" {
+		t.Errorf("Expected Render to drop styling for a non-terminal writer, got %q", rendered)
+	}
+}
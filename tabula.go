@@ -5,17 +5,41 @@ import (
 	"io"
 	"os"
 	"strings"
-	"unicode/utf8"
 )
 
 type Table struct {
-	borders         borders
-	columns         []string
-	columnAlignment map[string]int
-	headerAlignment map[string]int
-	rows            [][]string
-	columnWidths    map[string]int
-	// writer
+	borders           borders
+	columns           []string
+	columnAlignment   map[string]int
+	headerAlignment   map[string]int
+	verticalAlignment map[string]int
+	rows              [][]string
+	columnWidths      map[string]int
+	widthFunc         WidthFunc
+	headerStyle       Style
+	columnStyles      map[string]Style
+	rowStyles         map[int]Style
+	cellStyles        map[cellRef]Style
+	colorProfile      ColorProfile
+	maxWidths         map[string]int
+	overflow          map[string]Overflow
+	truncationMarker  *string
+	targetWidth       int
+	priorities        map[string]int
+	fittedWidths      map[string]int
+	decimalWidths     map[string][2]int
+	centerBias        int
+	footers           map[string]string
+	footerAggregates  map[string]AggregateFunc
+	footerAlignment   map[string]int
+	footerStyle       Style
+	hidden            map[string]bool
+	groupColumns      []string
+	groups            []rowGroup
+	subtotals         map[string]AggregateFunc
+	groupStyle        Style
+	subtotalStyle     Style
+	writer            io.Writer
 }
 
 type borders struct {
@@ -26,6 +50,7 @@ type borders struct {
 	showLeft       bool
 	showCenter     bool
 	showRight      bool
+	showFooter     bool
 
 	boldTop        bool
 	boldBottom     bool
@@ -34,10 +59,21 @@ type borders struct {
 	boldLeft       bool
 	boldCenter     bool
 	boldRight      bool
+	boldFooter     bool
 }
 
+// WidthFunc returns the number of terminal cells a string occupies.
 type WidthFunc func(string) int
 
+// tableWriter wraps the destination of a render, counting the bytes written and
+// holding on to the first error so the print helpers can stay terse.
+type tableWriter struct {
+	w       io.Writer
+	n       int64
+	err     error
+	profile ColorProfile
+}
+
 const (
 	Left = 1 << iota
 	Center
@@ -51,10 +87,17 @@ const (
 	Right2
 	Top2
 	Header2
-	Bottom2195
+	Bottom2
 	Horizontal2
+	Decimal // alignment only: lines numbers up on their decimal point
+	Footer  // border only: the line above the footer row
 )
 
+// Bottom2195 is the former name of Bottom2.
+//
+// Deprecated: use Bottom2.
+const Bottom2195 = Bottom2
+
 type debugCol struct {
 	ColName       string
 	Chars         int
@@ -66,11 +109,14 @@ var (
 	// DefaultPadding specifies the number of spaces around content in columns.
 	DefaultPadding = 1
 
-	// DefaultWriter specifies the output io.Writer for the Table.Print method.
+	// DefaultWriter specifies the output io.Writer for the Table.Print method
+	// when no writer has been set with Table.SetWriter.
 	DefaultWriter io.Writer = os.Stdout
 
-	// DefaultWidthFunc specifies the default WidthFunc for calculating column widths
-	DefaultWidthFunc WidthFunc = utf8.RuneCountInString
+	// DefaultWidthFunc specifies the default WidthFunc for calculating column
+	// widths. StringWidth accounts for wide characters, emoji and combining
+	// marks; utf8.RuneCountInString is a cheaper alternative for plain text.
+	DefaultWidthFunc WidthFunc = StringWidth
 )
 
 func GetAlignment(align int) (label string) {
@@ -81,6 +127,8 @@ func GetAlignment(align int) (label string) {
 		label = "Center"
 	case Right:
 		label = "Right"
+	case Decimal:
+		label = "Decimal"
 	}
 	return
 }
@@ -88,11 +136,13 @@ func GetAlignment(align int) (label string) {
 func NewTable(colNames ...string) (tbl *Table) {
 	columnAlignment := make(map[string]int)
 	headerAlignment := make(map[string]int)
+	verticalAlignment := make(map[string]int)
 	columnWidths := make(map[string]int)
 	for _, colName := range colNames {
 		columnAlignment[colName] = Left
 		headerAlignment[colName] = Left
-		columnWidths[colName] = len(colName)
+		verticalAlignment[colName] = Top
+		columnWidths[colName] = DefaultWidthFunc(colName)
 	}
 	tbl = &Table{
 		borders: borders{
@@ -103,6 +153,7 @@ func NewTable(colNames ...string) (tbl *Table) {
 			showLeft:       false,
 			showCenter:     false,
 			showRight:      false,
+			showFooter:     false,
 			boldTop:        false,
 			boldBottom:     false,
 			boldHeader:     false,
@@ -110,11 +161,23 @@ func NewTable(colNames ...string) (tbl *Table) {
 			boldLeft:       false,
 			boldCenter:     false,
 			boldRight:      false,
+			boldFooter:     false,
 		},
-		columns:         colNames,
-		columnAlignment: columnAlignment,
-		headerAlignment: headerAlignment,
-		columnWidths:    columnWidths,
+		columns:           colNames,
+		columnAlignment:   columnAlignment,
+		headerAlignment:   headerAlignment,
+		verticalAlignment: verticalAlignment,
+		columnWidths:      columnWidths,
+		columnStyles:      make(map[string]Style),
+		rowStyles:         make(map[int]Style),
+		cellStyles:        make(map[cellRef]Style),
+		maxWidths:         make(map[string]int),
+		overflow:          make(map[string]Overflow),
+		priorities:        make(map[string]int),
+		footers:           make(map[string]string),
+		footerAggregates:  make(map[string]AggregateFunc),
+		footerAlignment:   make(map[string]int),
+		subtotals:         make(map[string]AggregateFunc),
 	}
 
 	return
@@ -143,6 +206,9 @@ func (tbl *Table) SetBorder(border int, display bool, style bool) {
 	case Right:
 		tbl.borders.showRight = display
 		tbl.borders.boldRight = style
+	case Footer:
+		tbl.borders.showFooter = display
+		tbl.borders.boldFooter = style
 	}
 }
 
@@ -162,350 +228,417 @@ func (tbl *Table) Align(colName string, alignment int, includeHeader bool) {
 	}
 }
 
-func (tbl *Table) Print() {
-	tbl.FillWidths()
-	tbl.printTopBorder()
-	tbl.printHeaders()
-	tbl.printHeaderBorder()
-	tbl.printRows()
-	tbl.printBottomBorder()
-
-	// columnBaseTemplate := "│ %%%dv │ %%-%ds │ %%%ds │ %%-%ds │ %%-%ds │ %%-%ds │ %%-%ds │ %%-%ds │\n"
-	// line1BaseTemplate := "┏%s┳%s┳%s┳%s┳%s┳%s┳%s┳%s┓\n"
-	// line2BaseTemplate := "┡%s╇%s╇%s╇%s╇%s╇%s╇%s╇%s┩\n"
-	// line3BaseTemplate := "└%s┴%s┴%s┴%s┴%s┴%s┴%s┴%s┘\n"
+// Print renders the table to the writer set with SetWriter, falling back to
+// DefaultWriter. Styling is adapted to what the writer can display, see
+// DetectColorProfile.
+func (tbl *Table) Print() error {
+	return tbl.Fprint(tbl.output())
+}
+
+// Fprint renders the table to w, returning the first write error encountered.
+func (tbl *Table) Fprint(w io.Writer) (err error) {
+	_, err = tbl.WriteTo(w)
+	return
 }
 
-func (tbl *Table) printTopBorder() {
+// WriteTo renders the table to w. It implements io.WriterTo.
+func (tbl *Table) WriteTo(w io.Writer) (n int64, err error) {
+	return tbl.render(w, w)
+}
+
+// Render returns the table exactly as Print would write it, with styling and
+// automatic widths decided by the writer set with SetWriter or DefaultWriter.
+func (tbl *Table) Render() (string, error) {
+	var sb strings.Builder
+	_, err := tbl.render(&sb, tbl.output())
+	return sb.String(), err
+}
+
+// render writes the table to w, adapting color and width to what out can
+// display.
+func (tbl *Table) render(w io.Writer, out io.Writer) (n int64, err error) {
+	tbl = tbl.view()
+	tw := &tableWriter{w: w, profile: tbl.colorProfile}
+	if tw.profile == AutoColor {
+		tw.profile = DetectColorProfile(out)
+	}
+	tbl.fitWidths(out)
+	tbl.fillWidths()
+	tbl.printTopBorder(tw)
+	tbl.printHeaders(tw)
+	tbl.printHeaderBorder(tw)
+	tbl.printRows(tw)
+	tbl.printFooter(tw)
+	tbl.printBottomBorder(tw)
+	return tw.n, tw.err
+}
+
+// String returns the rendered table. It implements fmt.Stringer.
+func (tbl *Table) String() string {
+	out, _ := tbl.Render()
+	return out
+}
+
+// SetWriter sets the io.Writer used by Print. A nil writer restores DefaultWriter.
+func (tbl *Table) SetWriter(w io.Writer) {
+	tbl.writer = w
+}
+
+func (tbl *Table) output() io.Writer {
+	if tbl.writer != nil {
+		return tbl.writer
+	}
+	return DefaultWriter
+}
+
+func (tbl *Table) printTopBorder(tw *tableWriter) {
 	var calcWidth int
 	if tbl.borders.showTop {
 		if tbl.borders.showLeft {
 			if tbl.borders.boldTop && tbl.borders.boldLeft {
-				fmt.Print("┏")
+				tw.print("┏")
 			} else if tbl.borders.boldTop && !tbl.borders.boldLeft {
-				fmt.Print("┍")
+				tw.print("┍")
 			} else if !tbl.borders.boldTop && tbl.borders.boldLeft {
-				fmt.Print("┎")
+				tw.print("┎")
 			} else {
-				fmt.Print("┌")
+				tw.print("┌")
 			}
 		}
 		for i := 0; i < len(tbl.columns); i++ {
-			calcWidth, _ = tbl.CalcWidth(tbl.columns[i], true, false)
+			calcWidth = tbl.calcWidth(tbl.columns[i], true)
 			if tbl.borders.boldTop {
-				fmt.Print(strings.Repeat("━", calcWidth))
+				tw.print(strings.Repeat("━", calcWidth))
 			} else {
-				fmt.Print(strings.Repeat("─", calcWidth))
+				tw.print(strings.Repeat("─", calcWidth))
 			}
 			if tbl.borders.showCenter && i < len(tbl.columns)-1 {
 				if tbl.borders.boldTop && tbl.borders.boldCenter {
-					fmt.Print("┳")
+					tw.print("┳")
 				} else if tbl.borders.boldTop && !tbl.borders.boldCenter {
-					fmt.Print("┯")
+					tw.print("┯")
 				} else if !tbl.borders.boldTop && tbl.borders.boldCenter {
-					fmt.Print("┰")
+					tw.print("┰")
 				} else {
-					fmt.Print("┬")
+					tw.print("┬")
 				}
 			}
 		}
 		if tbl.borders.showRight {
 			if tbl.borders.boldTop && tbl.borders.boldRight {
-				fmt.Print("┓")
+				tw.print("┓")
 			} else if tbl.borders.boldTop && !tbl.borders.boldRight {
-				fmt.Print("┑")
+				tw.print("┑")
 			} else if !tbl.borders.boldTop && tbl.borders.boldRight {
-				fmt.Print("┒")
+				tw.print("┒")
 			} else {
-				fmt.Print("┐")
+				tw.print("┐")
 			}
 		}
-		fmt.Print("\n")
+		tw.print("\n")
 	}
 
 }
 
-func (tbl *Table) printHeaderBorder() {
+func (tbl *Table) printHeaderBorder(tw *tableWriter) {
 	var calcWidth int
 	if tbl.borders.showHeader {
 		if tbl.borders.showLeft && tbl.borders.showTop {
 			if tbl.borders.boldHeader && tbl.borders.boldLeft {
-				fmt.Print("┣")
+				tw.print("┣")
 			} else if tbl.borders.boldHeader && !tbl.borders.boldLeft {
-				fmt.Print("┝")
+				tw.print("┝")
 			} else if !tbl.borders.boldHeader && tbl.borders.boldLeft {
-				fmt.Print("┠")
+				tw.print("┠")
 			} else {
-				fmt.Print("├")
+				tw.print("├")
 			}
 		} else if tbl.borders.showLeft && !tbl.borders.showTop {
 			if tbl.borders.boldHeader && tbl.borders.boldLeft {
-				fmt.Print("┏")
+				tw.print("┏")
 			} else if tbl.borders.boldHeader && !tbl.borders.boldLeft {
-				fmt.Print("┍")
+				tw.print("┍")
 			} else if !tbl.borders.boldHeader && tbl.borders.boldLeft {
-				fmt.Print("┎")
+				tw.print("┎")
 			} else {
-				fmt.Print("┌")
+				tw.print("┌")
 			}
 		}
 		for i := 0; i < len(tbl.columns); i++ {
-			calcWidth, _ = tbl.CalcWidth(tbl.columns[i], true, false)
+			calcWidth = tbl.calcWidth(tbl.columns[i], true)
 			if tbl.borders.boldHeader {
-				fmt.Print(strings.Repeat("━", calcWidth))
+				tw.print(strings.Repeat("━", calcWidth))
 			} else {
-				fmt.Print(strings.Repeat("─", calcWidth))
+				tw.print(strings.Repeat("─", calcWidth))
 			}
 			if tbl.borders.showCenter && i < len(tbl.columns)-1 {
 				if tbl.borders.showCenter { //&& tbl.borders.showTop {
 					if tbl.borders.boldHeader && tbl.borders.boldCenter {
-						fmt.Print("╋")
+						tw.print("╋")
 					} else if tbl.borders.boldHeader && !tbl.borders.boldCenter {
-						fmt.Print("┿")
+						tw.print("┿")
 					} else if !tbl.borders.boldHeader && tbl.borders.boldCenter {
-						fmt.Print("╂")
+						tw.print("╂")
 					} else {
-						fmt.Print("┼")
+						tw.print("┼")
 					}
 					// } else if tbl.borders.showCenter && !tbl.borders.showTop {
 					// 	if tbl.borders.boldHeader && tbl.borders.boldCenter {
-					// 		fmt.Print("┳")
+					// 		tw.print("┳")
 					// 	} else if tbl.borders.boldHeader && !tbl.borders.boldCenter {
-					// 		fmt.Print("┯")
+					// 		tw.print("┯")
 					// 	} else if !tbl.borders.boldHeader && tbl.borders.boldCenter {
-					// 		fmt.Print("┰")
+					// 		tw.print("┰")
 					// 	} else {
-					// 		fmt.Print("┬")
+					// 		tw.print("┬")
 					// 	}
 					// } else if !tbl.borders.showCenter && tbl.borders.showTop {
 				} else {
 					if tbl.borders.boldHeader && tbl.borders.boldCenter {
-						fmt.Print("━")
+						tw.print("━")
 					} else if tbl.borders.boldHeader && !tbl.borders.boldCenter {
-						fmt.Print("%")
+						tw.print("%")
 					} else if !tbl.borders.boldHeader && tbl.borders.boldCenter {
-						fmt.Print("@")
+						tw.print("@")
 					} else {
-						fmt.Print("─")
+						tw.print("─")
 					}
 				}
 			}
 		}
 		if tbl.borders.showRight && tbl.borders.showTop {
 			if tbl.borders.boldHeader && tbl.borders.boldRight {
-				fmt.Print("┫")
+				tw.print("┫")
 			} else if tbl.borders.boldHeader && !tbl.borders.boldRight {
-				fmt.Print("┩")
+				tw.print("┩")
 			} else if !tbl.borders.boldHeader && tbl.borders.boldRight {
-				fmt.Print("┨")
+				tw.print("┨")
 			} else {
-				fmt.Print("┤")
+				tw.print("┤")
 			}
 		} else if tbl.borders.showRight && !tbl.borders.showTop {
 			if tbl.borders.boldHeader && tbl.borders.boldRight {
-				fmt.Print("┓")
+				tw.print("┓")
 			} else if tbl.borders.boldHeader && !tbl.borders.boldRight {
-				fmt.Print("┑")
+				tw.print("┑")
 			} else if !tbl.borders.boldHeader && tbl.borders.boldRight {
-				fmt.Print("┒")
+				tw.print("┒")
 			} else {
-				fmt.Print("┐")
+				tw.print("┐")
 			}
 		}
 
-		fmt.Print("\n")
+		tw.print("\n")
 	}
 }
 
-func (tbl *Table) printBottomBorder() {
+func (tbl *Table) printBottomBorder(tw *tableWriter) {
 	var calcWidth int
 	if tbl.borders.showBottom {
 		if tbl.borders.showLeft {
 			if tbl.borders.boldBottom && tbl.borders.boldLeft {
-				fmt.Print("┗")
+				tw.print("┗")
 			} else if tbl.borders.boldBottom && !tbl.borders.boldLeft {
-				fmt.Print("┕")
+				tw.print("┕")
 			} else if !tbl.borders.boldBottom && tbl.borders.boldLeft {
-				fmt.Print("┖")
+				tw.print("┖")
 			} else {
-				fmt.Print("└")
+				tw.print("└")
 			}
 		}
 		for i := 0; i < len(tbl.columns); i++ {
-			calcWidth, _ = tbl.CalcWidth(tbl.columns[i], true, false)
+			calcWidth = tbl.calcWidth(tbl.columns[i], true)
 			if tbl.borders.boldBottom {
-				fmt.Print(strings.Repeat("━", calcWidth))
+				tw.print(strings.Repeat("━", calcWidth))
 			} else {
-				fmt.Print(strings.Repeat("─", calcWidth))
+				tw.print(strings.Repeat("─", calcWidth))
 			}
 			if tbl.borders.showCenter && i < len(tbl.columns)-1 {
 				if tbl.borders.boldBottom && tbl.borders.boldCenter {
-					fmt.Print("┻")
+					tw.print("┻")
 				} else if tbl.borders.boldBottom && !tbl.borders.boldCenter {
-					fmt.Print("┷")
+					tw.print("┷")
 				} else if !tbl.borders.boldBottom && tbl.borders.boldCenter {
-					fmt.Print("┸")
+					tw.print("┸")
 				} else {
-					fmt.Print("┴")
+					tw.print("┴")
 				}
 			}
 		}
 		if tbl.borders.showRight {
 			if tbl.borders.boldBottom && tbl.borders.boldRight {
-				fmt.Print("┛")
+				tw.print("┛")
 			} else if tbl.borders.boldBottom && !tbl.borders.boldRight {
-				fmt.Print("┙")
+				tw.print("┙")
 			} else if !tbl.borders.boldBottom && tbl.borders.boldRight {
-				fmt.Print("┚")
+				tw.print("┚")
 			} else {
-				fmt.Print("┘")
+				tw.print("┘")
 			}
 			// } else {
 			// 	if tbl.borders.boldTop {
-			// 		fmt.Print("━")
+			// 		tw.print("━")
 			// 	} else {
-			// 		fmt.Print("─")
+			// 		tw.print("─")
 			// 	}
 		}
-		fmt.Print("\n")
+		tw.print("\n")
 	}
 
 }
 
-func (tbl *Table) printHeaders() {
-	if tbl.borders.showLeft {
-		if tbl.borders.boldLeft {
-			fmt.Print("┃")
-		} else {
-			fmt.Print("│")
-		}
+func (tbl *Table) printHeaders(tw *tableWriter) {
+	styles := make([]Style, len(tbl.columns))
+	for i := range styles {
+		styles[i] = tbl.headerStyle
 	}
-	for i := 0; i < len(tbl.columns); i++ {
-		fmt.Printf(
-			fmt.Sprintf(
-				"%s%%%s%ds%s",
-				strings.Repeat(" ", tbl.Padding(true, i)),
-				ternary(tbl.headerAlignment[tbl.columns[i]] == 1, "-", "").(string),
-				tbl.columnWidths[tbl.columns[i]],
-				strings.Repeat(" ", tbl.Padding(false, i)),
-			),
-			tbl.columns[i],
-		)
-		if i < len(tbl.columns)-1 {
-			if tbl.borders.showCenter {
-				if tbl.borders.boldCenter {
-					fmt.Print("┃")
-				} else {
-					fmt.Print("│")
-				}
-			}
-		}
-	}
-	if tbl.borders.showRight {
-		if tbl.borders.boldRight {
-			fmt.Print("┃")
-		} else {
-			fmt.Print("│")
-		}
-	}
-	fmt.Print("\n")
+	tbl.printLines(tw, tbl.columns, tbl.headerAlignment, styles)
 }
 
-func (tbl *Table) printRows() {
+func (tbl *Table) printRows(tw *tableWriter) {
+	if groups := tbl.rowGroups(); groups != nil {
+		tbl.printGroups(tw, groups)
+		return
+	}
 	for i := 0; i < len(tbl.rows); i++ {
-		tbl.printCells(i)
+		tbl.printCells(tw, i)
 		if i < len(tbl.rows)-1 {
-			tbl.printHorizontal()
+			tbl.printHorizontal(tw)
 		}
 	}
 }
 
-func (tbl *Table) printCells(rowNum int) {
-	if tbl.borders.showLeft {
-		if tbl.borders.boldLeft {
-			fmt.Print("┃")
-		} else {
-			fmt.Print("│")
-		}
+func (tbl *Table) printCells(tw *tableWriter, rowNum int) {
+	cells := make([]string, len(tbl.columns))
+	styles := make([]Style, len(tbl.columns))
+	for i, colName := range tbl.columns {
+		cells[i] = tbl.decimalText(tbl.rows[rowNum][i], colName)
+		styles[i] = tbl.cellStyle(rowNum, colName)
 	}
-	for i := 0; i < len(tbl.columns); i++ {
-		fmt.Printf(
-			fmt.Sprintf(
-				"%s%%%s%ds%s",
-				strings.Repeat(" ", tbl.Padding(true, i)),
-				ternary(tbl.columnAlignment[tbl.columns[i]] == 1, "-", "").(string),
-				tbl.columnWidths[tbl.columns[i]],
-				strings.Repeat(" ", tbl.Padding(false, i)),
-			),
-			tbl.rows[rowNum][i],
-		)
-		if i < len(tbl.columns)-1 {
-			if tbl.borders.showCenter {
-				if tbl.borders.boldCenter {
-					fmt.Print("┃")
-				} else {
-					fmt.Print("│")
-				}
-			}
-		}
+	tbl.printLines(tw, cells, tbl.columnAlignment, styles)
+}
+
+// printLines prints a logical row, which takes as many physical lines as its
+// tallest cell once split and wrapped. Shorter cells are padded vertically
+// according to their column's vertical alignment.
+func (tbl *Table) printLines(tw *tableWriter, cells []string, alignment map[string]int, styles []Style) {
+	lines := make([][]string, len(cells))
+	height := 1
+	for i, cell := range cells {
+		lines[i] = tbl.cellLines(cell, tbl.columns[i])
+		height = max(height, len(lines[i]))
 	}
 
-	if tbl.borders.showRight {
-		if tbl.borders.boldRight {
-			fmt.Print("┃")
-		} else {
-			fmt.Print("│")
+	offsets := make([]int, len(cells))
+	for i, colName := range tbl.columns {
+		switch tbl.verticalAlignment[colName] {
+		case Center:
+			offsets[i] = (height - len(lines[i])) / 2
+		case Bottom:
+			offsets[i] = height - len(lines[i])
 		}
 	}
-	fmt.Print("\n")
-}
 
-func (tbl *Table) printHorizontal() {
-	var calcWidth int
-	if tbl.borders.showHorizontal {
+	for line := 0; line < height; line++ {
 		if tbl.borders.showLeft {
-			if tbl.borders.boldHorizontal && tbl.borders.boldLeft {
-				fmt.Print("┣")
-			} else if tbl.borders.boldHorizontal && !tbl.borders.boldLeft {
-				fmt.Print("┝")
-			} else if !tbl.borders.boldHorizontal && tbl.borders.boldLeft {
-				fmt.Print("┠")
+			if tbl.borders.boldLeft {
+				tw.print("┃")
 			} else {
-				fmt.Print("├")
+				tw.print("│")
 			}
 		}
-
 		for i := 0; i < len(tbl.columns); i++ {
-			calcWidth, _ = tbl.CalcWidth(tbl.columns[i], true, false)
-			if tbl.borders.boldHorizontal {
-				fmt.Print(strings.Repeat("━", calcWidth))
-			} else {
-				fmt.Print(strings.Repeat("─", calcWidth))
+			text := ""
+			if n := line - offsets[i]; n >= 0 && n < len(lines[i]) {
+				text = lines[i][n]
 			}
-			if tbl.borders.showCenter && i < len(tbl.columns)-1 {
-				if tbl.borders.boldHorizontal && tbl.borders.boldCenter {
-					fmt.Print("╋")
-				} else if tbl.borders.boldHorizontal && !tbl.borders.boldCenter {
-					fmt.Print("┿")
-				} else if !tbl.borders.boldHorizontal && tbl.borders.boldCenter {
-					fmt.Print("╂")
-				} else {
-					fmt.Print("┼")
+			tw.print(
+				strings.Repeat(" ", tbl.padding(true, i)),
+				tbl.alignCell(styles[i].apply(text, tw.profile), tbl.columnWidths[tbl.columns[i]], alignment[tbl.columns[i]]),
+				strings.Repeat(" ", tbl.padding(false, i)),
+			)
+			if i < len(tbl.columns)-1 {
+				if tbl.borders.showCenter {
+					if tbl.borders.boldCenter {
+						tw.print("┃")
+					} else {
+						tw.print("│")
+					}
 				}
 			}
 		}
+
 		if tbl.borders.showRight {
-			if tbl.borders.boldHorizontal && tbl.borders.boldRight {
-				fmt.Print("┫")
-			} else if tbl.borders.boldHorizontal && !tbl.borders.boldRight {
-				fmt.Print("┥")
-			} else if !tbl.borders.boldHorizontal && tbl.borders.boldRight {
-				fmt.Print("┨")
+			if tbl.borders.boldRight {
+				tw.print("┃")
 			} else {
-				fmt.Print("┤")
+				tw.print("│")
 			}
 		}
-		fmt.Print("\n")
+		tw.print("\n")
+	}
+}
+
+func (tbl *Table) printHorizontal(tw *tableWriter) {
+	if tbl.borders.showHorizontal {
+		tbl.printRule(tw, tbl.borders.boldHorizontal)
 	}
+}
 
+// printRule prints a line between rows, joined to the left, center and right
+// borders.
+func (tbl *Table) printRule(tw *tableWriter, bold bool) {
+	var calcWidth int
+	if tbl.borders.showLeft {
+		if bold && tbl.borders.boldLeft {
+			tw.print("┣")
+		} else if bold && !tbl.borders.boldLeft {
+			tw.print("┝")
+		} else if !bold && tbl.borders.boldLeft {
+			tw.print("┠")
+		} else {
+			tw.print("├")
+		}
+	}
+
+	for i := 0; i < len(tbl.columns); i++ {
+		calcWidth = tbl.calcWidth(tbl.columns[i], true)
+		if bold {
+			tw.print(strings.Repeat("━", calcWidth))
+		} else {
+			tw.print(strings.Repeat("─", calcWidth))
+		}
+		if tbl.borders.showCenter && i < len(tbl.columns)-1 {
+			if bold && tbl.borders.boldCenter {
+				tw.print("╋")
+			} else if bold && !tbl.borders.boldCenter {
+				tw.print("┿")
+			} else if !bold && tbl.borders.boldCenter {
+				tw.print("╂")
+			} else {
+				tw.print("┼")
+			}
+		}
+	}
+	if tbl.borders.showRight {
+		if bold && tbl.borders.boldRight {
+			tw.print("┫")
+		} else if bold && !tbl.borders.boldRight {
+			tw.print("┥")
+		} else if !bold && tbl.borders.boldRight {
+			tw.print("┨")
+		} else {
+			tw.print("┤")
+		}
+	}
+	tw.print("\n")
+}
+
+func (tw *tableWriter) print(a ...interface{}) {
+	if tw.err != nil {
+		return
+	}
+	n, err := fmt.Fprint(tw.w, a...)
+	tw.n += int64(n)
+	tw.err = err
 }
@@ -1,11 +1,12 @@
 package tables
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"testing"
-	"time"
 )
 
 func TestOneTable(t *testing.T) {
@@ -113,7 +114,6 @@ func TestNewTable(t *testing.T) {
 	// test = Left + Right
 	// match = test | Left
 	// fmt.Printf("%d vs %d ", match, test)
-	line := 0
 	var done []int
 	var next int
 
@@ -123,16 +123,66 @@ func TestNewTable(t *testing.T) {
 			if getSliceIndexInt(next, done) == -1 {
 				done = append(done, next)
 				printWithConf(tbl, (j&Left != 0), (j&Center != 0), (j&Right != 0), (j&Top != 0), (j&Header != 0), (j&Bottom != 0), (j&Horizontal != 0), (i&Left != 0), (i&Center != 0), (i&Right != 0), (i&Top != 0), (i&Header != 0), (i&Bottom != 0), (i&Horizontal != 0))
-				if line%3 == 2 {
-					time.Sleep(1 * time.Second)
-				}
-				line++
 			}
 		}
 	}
 
 }
 
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestFprint(t *testing.T) {
+	tbl := NewTable("Name", "Value")
+	tbl.AddRow("pi", "3.14")
+	tbl.SetBorder(Left, true, false)
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Right, true, false)
+	tbl.SetBorder(Top, true, false)
+	tbl.SetBorder(Bottom, true, false)
+
+	var buf bytes.Buffer
+	if err := tbl.Fprint(&buf); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := "┌──────┬───────┐\n" +
+		"│ Name │ Value │\n" +
+		"│ pi   │ 3.14  │\n" +
+		"└──────┴───────┘\n"
+	if buf.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	var out bytes.Buffer
+	tbl.SetWriter(&out)
+	if err := tbl.Print(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if out.String() != expected {
+		t.Errorf("Print did not honor SetWriter, got\n%s", out.String())
+	}
+
+	rendered, err := tbl.Render()
+	if err != nil || rendered != expected {
+		t.Errorf("Render returned %q, %v", rendered, err)
+	}
+	if tbl.String() != expected || fmt.Sprint(tbl) != expected {
+		t.Errorf("String did not match Print, got\n%s", tbl.String())
+	}
+
+	n, err := tbl.WriteTo(&bytes.Buffer{})
+	if err != nil || n != int64(len(expected)) {
+		t.Errorf("Expected %d bytes and no error, got %d, %v", len(expected), n, err)
+	}
+
+	if err := tbl.Fprint(failingWriter{}); err == nil {
+		t.Error("Expected write error, got nothing")
+	}
+}
+
 func buildConf(sLeft, sCenter, sRight, sTop, sHeader, sBottom, sHorizonal bool, dLeft, dCenter, dRight, dTop, dHeader, dBottom, dHorizonal bool) (displayConf int) {
 	if dLeft {
 		displayConf = displayConf + Left
@@ -280,3 +330,19 @@ func printWithConf(tbl *Table, sLeft, sCenter, sRight, sTop, sHeader, sBottom, s
 // Header+Bold, Vertical+Bold (HeaderBorder Missing)
 // Top, Header+Bold, Vertical (HeaderBorder Bold Vertical on middle & end)
 // Top+Bold, Header+Bold, Vertical (HeaderBorder Bold Vertical on middle & end)
+
+func TestExportedHelpers(t *testing.T) {
+	tbl := NewTable("Name", "Value")
+	tbl.AddRow("pi", "3.14159")
+	tbl.SetBorder(Center, true, false)
+	tbl.FillWidths()
+	if width, debug := tbl.CalcWidth("Value", true, false); width != 8 || debug.Chars != 7 || debug.PaddingBefore != 1 {
+		t.Errorf("Expected width 8 from 7 characters, got %d %+v", width, debug)
+	}
+	if tbl.Padding(false, 0) != 1 || tbl.Padding(true, 0) != 0 {
+		t.Error("Expected padding only after the first column")
+	}
+	if Bottom2195 != Bottom2 {
+		t.Error("Expected Bottom2195 to alias Bottom2")
+	}
+}
@@ -0,0 +1,30 @@
+//go:build linux
+// +build linux
+
+package tables
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// isTTY reports whether f is a terminal, by asking for its terminal attributes.
+func isTTY(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TCGETS), uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
+
+// ttyWidth asks the terminal behind f for its width, returning 0 if f isn't a
+// terminal.
+func ttyWidth(f *os.File) int {
+	var size struct {
+		rows, cols, xpixel, ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+	return int(size.cols)
+}
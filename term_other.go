@@ -0,0 +1,20 @@
+//go:build !linux
+// +build !linux
+
+package tables
+
+import (
+	"os"
+)
+
+// isTTY reports whether f is a character device. Without a terminal ioctl this
+// can't tell terminals from other devices such as /dev/null.
+func isTTY(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ttyWidth is only implemented on Linux; elsewhere the width comes from COLUMNS.
+func ttyWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,372 @@
+package tables
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAggregates(t *testing.T) {
+	values := []string{"1,200.5", "3", "", "n/a", "-4.25", "3"}
+	cases := []struct {
+		name      string
+		aggregate AggregateFunc
+		expected  string
+	}{
+		{"Sum", Sum, "1202.25"},
+		{"Avg", Avg, "300.5625"},
+		{"Min", Min, "-4.25"},
+		{"Max", Max, "1,200.5"},
+		{"Count", Count, "5"},
+		{"CountDistinct", CountDistinct, "4"},
+	}
+	for _, c := range cases {
+		if result := c.aggregate(values); result != c.expected {
+			t.Errorf("%s - Expected %q, got %q", c.name, c.expected, result)
+		}
+	}
+	if result := Sum([]string{"a", ""}); result != "" {
+		t.Errorf("Expected no sum without numbers, got %q", result)
+	}
+}
+
+func TestFooter(t *testing.T) {
+	tbl := NewTable("Item", "Price")
+	tbl.AddRow("apple", "0.5")
+	tbl.AddRow("melon", "12.25")
+	tbl.AddRow("kiwi", "100")
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Bottom, true, false)
+	tbl.SetBorder(Footer, true, true)
+	tbl.Align("Price", Decimal, false)
+	tbl.SetFooter("Item", "Total")
+	tbl.SetFooterAggregate("Price", Sum)
+
+	out, _ := tbl.Render()
+	expected := "Item  │ Price \n" +
+		"apple │   0.5 \n" +
+		"melon │  12.25\n" +
+		"kiwi  │ 100   \n" +
+		"━━━━━━┿━━━━━━━\n" +
+		"Total │ 112.75\n" +
+		"──────┴───────\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.AlignFooter("Item", Right)
+	tbl.SetFooterAggregate("Item", Count)
+	out, _ = tbl.Render()
+	if !strings.Contains(out, "    3 │ 112.75") {
+		t.Errorf("Expected a right aligned count in the footer, got\n%s", out)
+	}
+
+	html, _ := tbl.HTML(HTMLOptions{})
+	if !strings.Contains(html, "<tfoot>\n    <tr>\n      <td style=\"text-align: right;\">3</td>") {
+		t.Errorf("Expected a <tfoot> section, got\n%s", html)
+	}
+}
+
+func TestComparators(t *testing.T) {
+	cases := []struct {
+		name    string
+		compare Comparator
+		a, b    string
+	}{
+		{"String", CompareString, "apple", "banana"},
+		{"Natural", CompareNatural, "file2", "file10"},
+		{"Natural", CompareNatural, "v1.2.9", "v1.10.0"},
+		{"Natural", CompareNatural, "item", "item1"},
+		{"Numeric", CompareNumeric, "9.5", "10"},
+		{"Numeric", CompareNumeric, "-3", "n/a"},
+		{"Bytes", CompareBytes, "900K", "1.2M"},
+		{"Bytes", CompareBytes, "1000 B", "1KiB"},
+		{"Duration", CompareDuration, "90s", "1h"},
+		{"Date", CompareDate, "2021-12-31", "2022-01-01T00:00:00Z"},
+		{"Date", CompareDate, "Jan 2, 2006", "01/03/2006"},
+	}
+	for _, c := range cases {
+		if c.compare(c.a, c.b) >= 0 || c.compare(c.b, c.a) <= 0 {
+			t.Errorf("%s - Expected %q to sort before %q", c.name, c.a, c.b)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	tbl := NewTable("Team", "Name", "Score")
+	tbl.AddRow("red", "ann", "10")
+	tbl.AddRow("blue", "bob", "9")
+	tbl.AddRow("red", "cid", "100")
+	tbl.AddRow("blue", "dee", "9")
+	tbl.SetRowStyle(0, Style{Bold: true})
+	tbl.SetCellStyle(2, "Score", Style{Foreground: Red})
+
+	sorted, err := tbl.Sorted(SortKey{Column: "Team"}, SortKey{Column: "Score", Descending: true, Compare: CompareNumeric})
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := []string{"bob", "dee", "cid", "ann"}
+	for i, name := range expected {
+		if sorted.rows[i][1] != name {
+			t.Errorf("Row %d - Expected %q, got %q", i, name, sorted.rows[i][1])
+		}
+	}
+	if tbl.rows[0][1] != "ann" {
+		t.Error("Expected Sorted to leave the table untouched")
+	}
+	if !sorted.rowStyles[3].Bold || sorted.cellStyles[cellRef{2, "Score"}].Foreground != Red {
+		t.Errorf("Expected styles to move with their rows, got %v %v", sorted.rowStyles, sorted.cellStyles)
+	}
+
+	if err = tbl.SortBy(SortKey{Column: "Name", Descending: true}); err != nil || tbl.rows[0][1] != "dee" {
+		t.Errorf("Expected in place sort, got %q (%v)", tbl.rows, err)
+	}
+	if err = tbl.SortBy(SortKey{Column: "Missing"}); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+}
+
+func TestWhere(t *testing.T) {
+	tbl := NewTable("Host", "Status", "Load")
+	tbl.AddRow("db-1", "up", "0.9")
+	tbl.AddRow("web-1", "down", "12")
+	tbl.AddRow("db-2", "down", "3")
+	tbl.AddRow("web-2", "up", "2.5")
+	tbl.SetRowStyle(2, Style{Bold: true})
+
+	cases := []struct {
+		expr     string
+		expected []string
+	}{
+		{`Status = up`, []string{"db-1", "web-2"}},
+		{`Load > 2.5`, []string{"web-1", "db-2"}},
+		{`Load <= 3 and Status != up`, []string{"db-2"}},
+		{`Host ~ "^db-" or Load >= 12`, []string{"db-1", "web-1", "db-2"}},
+		{`Status = up or Status = down and Load < 5`, []string{"db-1", "db-2", "web-2"}},
+		{`(Status = up or Status = down) and Load < 1`, []string{"db-1"}},
+		{`not Host ~ db`, []string{"web-1", "web-2"}},
+		{`Host = "no such host"`, nil},
+	}
+	for _, c := range cases {
+		filtered, err := tbl.Where(c.expr)
+		if err != nil {
+			t.Errorf("%s - Expected no error, got %v", c.expr, err)
+			continue
+		}
+		var hosts []string
+		for _, row := range filtered.rows {
+			hosts = append(hosts, row[0])
+		}
+		if strings.Join(hosts, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("%s - Expected %q, got %q", c.expr, c.expected, hosts)
+		}
+	}
+
+	filtered, _ := tbl.Where(`Status = down`)
+	if !filtered.rowStyles[1].Bold || len(tbl.rows) != 4 {
+		t.Error("Expected styles to follow their rows and the table to be untouched")
+	}
+
+	for _, expr := range []string{`Missing = 1`, `Host ~ "("`, `Status =`, `Status up`, `(Status = up`, `Host = "db-1`, `Status => up`} {
+		if _, err := tbl.Where(expr); err == nil {
+			t.Errorf("%s - Expected error, got nothing", expr)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tbl := NewTable("Name", "Age")
+	tbl.AddRow("ann", "31")
+	tbl.AddRow("bob", "17")
+	adult, _ := ParseFilter("Age >= 18")
+	filtered := tbl.Filter(adult, func(row map[string]string) bool {
+		return row["Name"] != ""
+	})
+	if len(filtered.rows) != 1 || filtered.rows[0][0] != "ann" {
+		t.Errorf("Expected only ann, got %q", filtered.rows)
+	}
+}
+
+func TestColumnVisibility(t *testing.T) {
+	tbl := NewTable("ID", "Name", "Node", "IP")
+	tbl.AddRow("1", "api", "n1", "10.0.0.1")
+	tbl.AddRow("2", "worker", "n2", "10.0.0.2")
+	tbl.Align("ID", Right, true)
+	tbl.SetBorder(Header, true, false)
+
+	if err := tbl.HideColumns("Node", "IP"); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := "" +
+		"ID Name  \n" +
+		"─────────\n" +
+		" 1 api   \n" +
+		" 2 worker\n"
+	if out := tbl.String(); out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+	if md, _ := tbl.Markdown(); strings.Contains(md, "Node") {
+		t.Errorf("Expected hidden columns left out of Markdown, got\n%s", md)
+	}
+	var sb strings.Builder
+	tbl.WriteCSV(&sb, CSVOptions{})
+	if sb.String() != "ID,Name\n1,api\n2,worker\n" {
+		t.Errorf("Expected hidden columns left out of CSV, got %q", sb.String())
+	}
+
+	tbl.ShowColumns("IP")
+	if err := tbl.ReorderColumns("IP", "Name"); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if strings.Join(tbl.columns, ",") != "IP,Name,ID,Node" || strings.Join(tbl.rows[1], ",") != "10.0.0.2,worker,2,n2" {
+		t.Errorf("Expected reordered columns, got %q %q", tbl.columns, tbl.rows)
+	}
+	sb.Reset()
+	tbl.WriteCSV(&sb, CSVOptions{})
+	if sb.String() != "IP,Name,ID\n10.0.0.1,api,1\n10.0.0.2,worker,2\n" {
+		t.Errorf("Expected reordered CSV, got %q", sb.String())
+	}
+
+	tbl.ShowColumns()
+	projected, err := tbl.Project("Node", "ID")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if strings.Join(projected.columns, ",") != "Node,ID" || projected.rows[0][1] != "1" || projected.columnAlignment["ID"] != Right {
+		t.Errorf("Expected projection onto Node and ID, got %q %q", projected.columns, projected.rows)
+	}
+	if len(tbl.columns) != 4 {
+		t.Error("Expected Project to leave the table untouched")
+	}
+
+	for _, names := range [][]string{{"Missing"}, {"ID", "ID"}} {
+		if _, err := tbl.Project(names...); err == nil {
+			t.Errorf("%q - Expected error, got nothing", names)
+		}
+		if err := tbl.HideColumns(names...); err == nil {
+			t.Errorf("%q - Expected error, got nothing", names)
+		}
+	}
+	priced := NewTable("Item", "Price")
+	priced.AddRow("a", "1")
+	priced.AddRow("b", "2")
+	priced.SetBorder(Footer, true, false)
+	priced.SetFooterAggregate("Price", Sum)
+	priced.GroupBy("Item")
+	priced.SetSubtotal("Price", Sum)
+	priced.HideColumns("Price")
+	if out := priced.String(); out != "Item   \nItem: a\na      \nItem: b\nb      \n" {
+		t.Errorf("Expected no footer or subtotal rows for a hidden column, got %q", out)
+	}
+	if html, _ := priced.HTML(HTMLOptions{}); strings.Contains(html, "<tfoot>") {
+		t.Errorf("Expected no footer for a hidden column, got\n%s", html)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	tbl := NewTable("Customer", "Item", "Amount")
+	tbl.AddRow("acme", "hosting", "120.00")
+	tbl.AddRow("globex", "support", "80.50")
+	tbl.AddRow("acme", "domains", "15.25")
+	tbl.Align("Amount", Right, true)
+	for _, border := range []int{Top, Bottom, Left, Right, Center, Header, Horizontal} {
+		tbl.SetBorder(border, true, false)
+	}
+	if err := tbl.GroupBy("Customer"); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	tbl.SetSubtotal("Amount", Sum)
+	tbl.HideColumns("Customer")
+
+	expected := "" +
+		"┌─────────┬────────┐\n" +
+		"│ Item    │ Amount │\n" +
+		"├─────────┼────────┤\n" +
+		"│ Customer: acme   │\n" +
+		"│ hosting │ 120.00 │\n" +
+		"│ domains │  15.25 │\n" +
+		"│         │ 135.25 │\n" +
+		"├─────────┼────────┤\n" +
+		"│ Customer: globex │\n" +
+		"│ support │  80.50 │\n" +
+		"│         │  80.50 │\n" +
+		"└─────────┴────────┘\n"
+	if out := tbl.String(); out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	narrow := NewTable("K", "V")
+	narrow.AddRow("a", "1")
+	narrow.AddRow("b", "2")
+	narrow.SetBorder(Center, true, false)
+	narrow.GroupBy("K", "V")
+	expected = "" +
+		"K │ V     \n" +
+		"K: a, V: 1\n" +
+		"a │ 1     \n" +
+		"K: b, V: 2\n" +
+		"b │ 2     \n"
+	if out := narrow.String(); out != expected {
+		t.Errorf("Expected the table widened to fit group headers\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.ShowColumns()
+	for _, colName := range []string{"Customer", "Item", "Amount"} {
+		tbl.SetMaxWidth(colName, 3)
+		tbl.SetOverflow(colName, TruncateEnd)
+	}
+	lines := strings.Split(tbl.String(), "\n")
+	if lines[0] != "┌─────┬─────┬─────┐" || lines[3] != "│ Customer: acme  │" || lines[8] != "│ Customer: glob… │" {
+		t.Errorf("Expected group headers truncated to the table width, got\n%s", strings.Join(lines, "\n"))
+	}
+
+	if err := tbl.GroupBy("Missing"); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	tbl := NewTable("Metric", "Mon", "Tue")
+	tbl.AddRow("cpu", "40", "55")
+	tbl.AddRow("mem", "70", "72")
+	tbl.AddRow("cpu", "41", "50")
+
+	transposed := tbl.Transpose()
+	if strings.Join(transposed.columns, ",") != "Metric,cpu,mem,cpu (2)" {
+		t.Errorf("Expected de-duplicated column names, got %q", transposed.columns)
+	}
+	if len(transposed.rows) != 2 || strings.Join(transposed.rows[1], ",") != "Tue,55,72,50" {
+		t.Errorf("Expected a row per column, got %q", transposed.rows)
+	}
+	if back := transposed.Transpose(); strings.Join(back.rows[2], ",") != "cpu (2),41,50" {
+		t.Errorf("Expected transposing back to restore the rows, got %q", back.rows)
+	}
+}
+
+func TestPivot(t *testing.T) {
+	tbl := NewTable("Host", "Metric", "Value")
+	tbl.AddRow("web", "cpu", "40")
+	tbl.AddRow("db", "mem", "80")
+	tbl.AddRow("web", "mem", "60")
+	tbl.AddRow("web", "cpu", "20")
+	tbl.Align("Value", Right, true)
+
+	pivoted, err := tbl.Pivot("Host", "Metric", "Value", Sum)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := "" +
+		"Host cpu  mem\n" +
+		"web   60   60\n" +
+		"db         80\n"
+	if out := pivoted.String(); out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	last, _ := tbl.Pivot("Host", "Metric", "Value", nil)
+	if last.rows[0][1] != "20" {
+		t.Errorf("Expected the last value, got %q", last.rows[0][1])
+	}
+	if _, err = tbl.Pivot("Host", "Missing", "Value", Sum); err == nil {
+		t.Error("Expected error, got nothing")
+	}
+}
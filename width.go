@@ -0,0 +1,196 @@
+package tables
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// wideRanges lists the code points that occupy two terminal cells: the East Asian
+// Wide and Fullwidth ranges, plus the emoji that default to emoji presentation.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202}, {0x1F210, 0x1F23B},
+	{0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB},
+	{0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+const (
+	zeroWidthJoiner   = 0x200D
+	textPresentation  = 0xFE0E
+	emojiPresentation = 0xFE0F
+)
+
+// RuneWidth returns the number of terminal cells r occupies on its own: 0 for
+// control characters, combining marks and other format characters, 2 for East
+// Asian wide and fullwidth characters and emoji, and 1 otherwise.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x1100:
+		if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+			return 0
+		}
+		return 1
+	case r >= 0x1160 && r <= 0x11FF: // Hangul medial vowels and final consonants
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	if i < len(wideRanges) && wideRanges[i][0] <= r {
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the number of terminal cells s occupies. It measures whole
+// grapheme clusters rather than runes, so combining sequences, emoji joined with
+// zero width joiners, skin tone modifiers and flag pairs count as a single
+// character, and a variation selector can switch a symbol to its two cell
+// emoji presentation.
+func StringWidth(s string) (width int) {
+	clusterWidth := 0
+	joined := false   // the previous rune was a zero width joiner
+	regional := false // the cluster is a lone regional indicator
+	for _, r := range s {
+		switch {
+		case joined:
+			joined = false
+		case r == zeroWidthJoiner:
+			joined = true
+		case r == emojiPresentation && clusterWidth > 0:
+			clusterWidth = max(clusterWidth, 2)
+		case r == textPresentation, r >= 0x1F3FB && r <= 0x1F3FF && clusterWidth > 0:
+		case r >= 0x1F1E6 && r <= 0x1F1FF && regional: // second half of a flag
+			regional = false
+		case RuneWidth(r) == 0 && clusterWidth > 0:
+		default:
+			width += clusterWidth
+			clusterWidth = RuneWidth(r)
+			regional = r >= 0x1F1E6 && r <= 0x1F1FF
+			if regional {
+				clusterWidth = 2
+			}
+		}
+	}
+	return width + clusterWidth
+}
+
+// stringWidth measures s with the table's WidthFunc, or DefaultWidthFunc if none
+// has been set. Escape sequences are skipped so pre-colored cells measure the
+// same as plain ones.
+func (tbl *Table) stringWidth(s string) int {
+	s = StripANSI(s)
+	if tbl.widthFunc != nil {
+		return tbl.widthFunc(s)
+	}
+	return DefaultWidthFunc(s)
+}
+
+// SetWidthFunc sets the function used to measure cell contents. A nil function
+// restores DefaultWidthFunc.
+func (tbl *Table) SetWidthFunc(widthFunc WidthFunc) {
+	tbl.widthFunc = widthFunc
+}
+
+// alignCell pads text with spaces to width according to alignment. Decimal
+// aligned text has already been lined up by decimalText and is right aligned.
+func (tbl *Table) alignCell(text string, width int, alignment int) string {
+	fill := width - tbl.stringWidth(text)
+	if fill <= 0 {
+		return text
+	}
+	switch alignment {
+	case Left:
+		return text + strings.Repeat(" ", fill)
+	case Center:
+		before := fill / 2
+		if tbl.centerBias == Right {
+			before = fill - before
+		}
+		return strings.Repeat(" ", before) + text + strings.Repeat(" ", fill-before)
+	}
+	return strings.Repeat(" ", fill) + text
+}
+
+// SetCenterBias sets which way centered text leans when it can't sit exactly in
+// the middle: Left (the default) puts the odd space after the text and Right
+// puts it before.
+func (tbl *Table) SetCenterBias(bias int) {
+	tbl.centerBias = bias
+}
+
+// splitDecimal splits a numeric cell around its decimal point, reporting false
+// for cells that aren't numbers. The fraction keeps the point.
+func splitDecimal(text string) (integer, fraction string, ok bool) {
+	trimmed := strings.TrimSpace(text)
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(trimmed, ",", ""), 64); err != nil {
+		return "", "", false
+	}
+	if i := strings.IndexByte(trimmed, '.'); i >= 0 {
+		return trimmed[:i], trimmed[i:], true
+	}
+	return trimmed, "", true
+}
+
+// fillDecimalWidths measures the widest integer and fraction parts of every
+// Decimal aligned column, footer included when it is Decimal aligned too.
+func (tbl *Table) fillDecimalWidths() {
+	tbl.decimalWidths = make(map[string][2]int)
+	summaries := tbl.summaryRows()
+	footerAlignment := tbl.footerAlignments()
+	for col, colName := range tbl.columns {
+		if tbl.columnAlignment[colName] != Decimal {
+			continue
+		}
+		values := columnValues(tbl.rows, col)
+		if footerAlignment[colName] == Decimal {
+			values = append(values, columnValues(summaries, col)...)
+		}
+		var widths [2]int
+		for _, val := range values {
+			if integer, fraction, ok := splitDecimal(val); ok {
+				widths[0] = max(widths[0], tbl.stringWidth(integer))
+				widths[1] = max(widths[1], tbl.stringWidth(fraction))
+			}
+		}
+		tbl.decimalWidths[colName] = widths
+	}
+}
+
+// decimalText pads numbers in Decimal aligned columns so that, once right
+// aligned, their decimal points line up. Other cells are returned unchanged.
+func (tbl *Table) decimalText(text string, colName string) string {
+	widths, ok := tbl.decimalWidths[colName]
+	if !ok {
+		return text
+	}
+	integer, fraction, ok := splitDecimal(text)
+	if !ok {
+		return text
+	}
+	return strings.Repeat(" ", widths[0]-tbl.stringWidth(integer)) + integer + fraction +
+		strings.Repeat(" ", widths[1]-tbl.stringWidth(fraction))
+}
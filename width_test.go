@@ -0,0 +1,89 @@
+package tables
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestStringWidth(t *testing.T) {
+	cases := map[string]int{
+		"":                 0,
+		"abc":              3,
+		"日本語":              6,
+		"ｈｉ":               4,
+		"e\u0301":          1, // e + combining acute accent
+		"👍":                2,
+		"👍🏽":               2, // skin tone modifier
+		"👩\u200d💻":         2, // zero width joiner sequence
+		"🇯🇵":               2, // regional indicator pair
+		"❤\ufe0f":          2, // heart with emoji presentation
+		"❤":                1,
+		"한국어":              6,
+		"a\u200bb":         2, // zero width space
+		"tab\tcontrol\x07": 10,
+	}
+	for s, expected := range cases {
+		if width := StringWidth(s); width != expected {
+			t.Errorf("StringWidth(%q) - Expected %d, got %d", s, expected, width)
+		}
+	}
+}
+
+func TestWideCharacterLayout(t *testing.T) {
+	tbl := NewTable("Name", "City")
+	tbl.AddRow("山田", "東京")
+	tbl.AddRow("Zoë", "Paris 🇫🇷")
+	tbl.SetBorder(Left, true, false)
+	tbl.SetBorder(Center, true, false)
+	tbl.SetBorder(Right, true, false)
+
+	out, _ := tbl.Render()
+	expected := "│ Name │ City     │\n" +
+		"│ 山田 │ 東京     │\n" +
+		"│ Zoë  │ Paris 🇫🇷 │\n"
+	if out != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+
+	tbl.SetWidthFunc(utf8.RuneCountInString)
+	out, _ = tbl.Render()
+	expected = "│ Name │ City     │\n" +
+		"│ 山田   │ 東京       │\n" +
+		"│ Zoë  │ Paris 🇫🇷 │\n"
+	if out != expected {
+		t.Errorf("Expected WidthFunc to be honored\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	cases := map[string]string{
+		"plain":                          "plain",
+		"\x1b[1;31mred\x1b[0m":           "red",
+		"\x1b[38;2;255;100;0mtrue\x1b[m": "true",
+		"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\": "link",
+		"\x1b]0;title\abody":  "body",
+		"\x1b(Bcharset":       "charset",
+		"\u009b32mc1\u009b0m": "c1",
+	}
+	for s, expected := range cases {
+		if stripped := StripANSI(s); stripped != expected {
+			t.Errorf("StripANSI(%q) - Expected %q, got %q", s, expected, stripped)
+		}
+	}
+}
+
+func TestColoredCellLayout(t *testing.T) {
+	tbl := NewTable("Status", "Host")
+	tbl.AddRow("\x1b[32mok\x1b[0m", "\x1b]8;;https://a.example\x1b\\a.example\x1b]8;;\x1b\\")
+	tbl.AddRow("failed", "b")
+	tbl.SetBorder(Center, true, false)
+	tbl.Align("Status", Right, false)
+
+	out, _ := tbl.Render()
+	expected := "Status │ Host     \n" +
+		"    \x1b[32mok\x1b[0m │ \x1b]8;;https://a.example\x1b\\a.example\x1b]8;;\x1b\\\n" +
+		"failed │ b        \n"
+	if out != expected {
+		t.Errorf("Expected %q, got %q", expected, out)
+	}
+}